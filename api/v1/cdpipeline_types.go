@@ -56,6 +56,35 @@ const (
 	Error Result = "error"
 )
 
+const (
+	// CreatedByAnnotation contains the name of the user who created the resource.
+	CreatedByAnnotation = "app.edp.epam.com/created-by"
+
+	// LastModifiedByAnnotation contains the name of the user who made the last change of the resource spec.
+	LastModifiedByAnnotation = "app.edp.epam.com/last-modified-by"
+
+	// SystemUsername is used if the user who changed the resource is unknown.
+	SystemUsername = "system"
+
+	// SecretManagerAnnotation overrides the secret manager of the CDPipeline stages.
+	// Possible values: own, eso, none.
+	SecretManagerAnnotation = "app.edp.epam.com/secret-manager"
+)
+
+// LastModifiedBy returns the name of the user who made the last change of the resource.
+// The annotations are set by the mutating webhook. If they are not set, SystemUsername is returned.
+func LastModifiedBy(obj metaV1.Object) string {
+	if user := obj.GetAnnotations()[LastModifiedByAnnotation]; user != "" {
+		return user
+	}
+
+	if user := obj.GetAnnotations()[CreatedByAnnotation]; user != "" {
+		return user
+	}
+
+	return SystemUsername
+}
+
 // CDPipelineStatus defines the observed state of CDPipeline.
 type CDPipelineStatus struct {
 	// This flag indicates neither CDPipeline are initialized and ready to work. Defaults to false.
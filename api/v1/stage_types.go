@@ -8,6 +8,10 @@ const (
 	StageCdPipelineLabelName = "app.edp.epam.com/cdPipelineName"
 	InCluster                = "in-cluster"
 
+	// StageReorderAnnotation is set on the CDPipeline to allow changing the order of its Stages.
+	// While it is set to "true", the order of the Stages is not validated.
+	StageReorderAnnotation = "app.edp.epam.com/stage-reorder"
+
 	// TriggerTypeAutoDeploy indicates auto deploy with all latest tags for all applications.
 	TriggerTypeAutoDeploy = "Auto"
 
@@ -45,6 +49,7 @@ type StageSpec struct {
 
 	// The order to lay out Stages.
 	// The order should start from 0, and the next stages should use +1 for the order.
+	// To change the order, the CDPipeline should have the app.edp.epam.com/stage-reorder: "true" annotation.
 	Order int `json:"order"`
 
 	// A list of quality gates to be processed
@@ -56,6 +61,7 @@ type StageSpec struct {
 	Source Source `json:"source"`
 
 	// Namespace where the application will be deployed.
+	// If it is not set, the mutating webhook generates it from the namespace template.
 	// +required
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	Namespace string `json:"namespace"`
@@ -77,6 +83,169 @@ type StageSpec struct {
 	// +optional
 	// +kubebuilder:default:="in-cluster"
 	ClusterName string `json:"clusterName,omitempty"`
+
+	// Clusters is a list of additional clusters where the application will be deployed.
+	// Every cluster is deployed together with the cluster specified in ClusterName.
+	// The stage is available only when all clusters are configured successfully.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	Clusters []ClusterTarget `json:"clusters,omitempty"`
+
+	// DeletionPolicy defines what happens with the Stage namespaces when the Stage is deleted.
+	// Delete - namespaces are deleted.
+	// Retain - namespaces are kept as is, clusters are not accessed during the Stage deletion.
+	// Orphan - namespaces are kept, but the tenant labels, including the Capsule Tenant label, are removed from them.
+	// +optional
+	// +kubebuilder:default:="Delete"
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// NamespaceProfile is a name of the ConfigMap with the namespace profile.
+	// The ConfigMap should be in the same namespace as the Stage.
+	// The profile defines labels, annotations, ResourceQuota, LimitRange,
+	// default-deny NetworkPolicy and Pod Security Admission levels of the Stage namespaces.
+	// +optional
+	NamespaceProfile string `json:"namespaceProfile,omitempty"`
+
+	// ImagePullSecrets is a list of Secrets with container registry credentials in the same namespace as the Stage.
+	// The Secrets are synced to the Stage namespaces by the secret manager
+	// and attached to the default ServiceAccount of the namespaces.
+	// If empty, the Secrets from the operator configuration are used.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// SecretStoreTemplate is a name of the ConfigMap with the SecretStore template used in the eso secret manager mode.
+	// The ConfigMap should be in the same namespace as the Stage.
+	// If empty, the secret-store-template ConfigMap is used if it exists in the Stage namespace,
+	// otherwise the SecretStore reads secrets from the Stage namespace with the kubernetes provider.
+	// +optional
+	SecretStoreTemplate string `json:"secretStoreTemplate,omitempty"`
+
+	// AccessControl is a list of bindings of subjects to roles in the Stage namespaces.
+	// If it is set, it replaces the default binding of the OIDC admin and developer groups to the admin ClusterRole.
+	// The operator removes the RoleBindings of the bindings that are removed from the list.
+	// It is not supported with the HNC tenancy engine, because the tenant RoleBindings are propagated from the parent namespace.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	AccessControl []AccessControlBinding `json:"accessControl,omitempty"`
+
+	// ServiceAccounts is a list of application ServiceAccounts created in the Stage namespaces.
+	// Annotations of the ServiceAccounts bind them to the cloud identities,
+	// e.g. eks.amazonaws.com/role-arn, iam.gke.io/gcp-service-account or azure.workload.identity/client-id.
+	// The names of the ServiceAccounts are available in the ApplicationSet generator elements as serviceAccounts.
+	// The operator removes the ServiceAccounts that are removed from the list.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	ServiceAccounts []StageServiceAccount `json:"serviceAccounts,omitempty"`
+
+	// Variables are free-form variables of the Stage, e.g. ingress domain, replica profile or feature toggles.
+	// They are available in the ApplicationSet generator elements of the Stage as variables,
+	// e.g. {{ .variables.ingressDomain }}.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=50
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k.matches('^[a-zA-Z_][a-zA-Z0-9_]*$'))",message="Variable names must be valid template identifiers"
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// MirrorConfigMap enables copying of the Stage environment metadata to the Stage namespaces
+	// as the stage-metadata ConfigMap, so applications and tests can discover their environment.
+	// +optional
+	MirrorConfigMap bool `json:"mirrorConfigMap,omitempty"`
+}
+
+// DeletionPolicy defines what happens with the Stage namespaces when the Stage is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the Stage namespaces.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+
+	// DeletionPolicyRetain keeps the Stage namespaces.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+
+	// DeletionPolicyOrphan keeps the Stage namespaces and removes the tenant labels from them.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// ClusterTarget defines an additional cluster where the application will be deployed.
+type ClusterTarget struct {
+	// Name of the cluster.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace where the application will be deployed in the cluster.
+	// If not specified, the stage namespace is used.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// AccessControlBinding binds subjects to a role in the Stage namespaces.
+type AccessControlBinding struct {
+	// Name of the binding. The RoleBinding in the Stage namespace is named access-control-<name>.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=48
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Subjects that are bound to the role.
+	// +kubebuilder:validation:MinItems=1
+	Subjects []AccessControlSubject `json:"subjects"`
+
+	// RoleRef is a reference to the ClusterRole or the Role in the Stage namespace.
+	// The role must be allowed in the operator configuration.
+	RoleRef AccessControlRoleRef `json:"roleRef"`
+}
+
+// AccessControlSubject is a subject of the access control binding.
+type AccessControlSubject struct {
+	// Kind of the subject.
+	// +kubebuilder:validation:Enum=Group;User;ServiceAccount
+	Kind string `json:"kind"`
+
+	// Name of the subject.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the ServiceAccount. Only ServiceAccounts of the Stage namespace can be bound,
+	// so it must be empty or equal to the Stage namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// AccessControlRoleRef is a reference to the role of the access control binding.
+type AccessControlRoleRef struct {
+	// Kind of the role.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterRole;Role
+	// +kubebuilder:default:="ClusterRole"
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the role.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// StageServiceAccount defines an application ServiceAccount in the Stage namespaces.
+type StageServiceAccount struct {
+	// Name of the ServiceAccount.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Name string `json:"name"`
+
+	// Annotations of the ServiceAccount.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels of the ServiceAccount.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // QualityGate defines a single quality for a release.
@@ -183,6 +352,48 @@ func (s *Stage) InCluster() bool {
 	return s.Spec.ClusterName == InCluster
 }
 
+// GetClusterTargets returns all clusters where the application will be deployed.
+// The first target is always the cluster specified in ClusterName.
+func (s *Stage) GetClusterTargets() []ClusterTarget {
+	targets := make([]ClusterTarget, 0, len(s.Spec.Clusters)+1)
+	targets = append(targets, ClusterTarget{
+		Name:      s.Spec.ClusterName,
+		Namespace: s.Spec.Namespace,
+	})
+
+	for _, target := range s.Spec.Clusters {
+		if target.Namespace == "" {
+			target.Namespace = s.Spec.Namespace
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets
+}
+
+// GetDeletionPolicy returns the Stage deletion policy. Delete is used if the policy is not set.
+func (s *Stage) GetDeletionPolicy() DeletionPolicy {
+	if s.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+
+	return s.Spec.DeletionPolicy
+}
+
+// ForClusterTarget returns a copy of the Stage with ClusterName and Namespace
+// set to the given target.
+func (s *Stage) ForClusterTarget(target ClusterTarget) *Stage {
+	stage := s.DeepCopy()
+	stage.Spec.ClusterName = target.Name
+
+	if target.Namespace != "" {
+		stage.Spec.Namespace = target.Namespace
+	}
+
+	return stage
+}
+
 func (s *Stage) IsManualTriggerType() bool {
 	return s.Spec.TriggerType == TriggerTypeManual
 }
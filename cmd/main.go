@@ -34,6 +34,7 @@ import (
 	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/argocd"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/aws"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/hnc"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/objectmodifier"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/util/cluster"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/webhook"
@@ -194,6 +195,7 @@ func main() {
 	utilruntime.Must(corev1.AddToScheme(scheme))
 	utilruntime.Must(projectApi.Install(scheme))
 	utilruntime.Must(argoApi.AddToScheme(scheme))
+	utilruntime.Must(hnc.AddToScheme(scheme))
 
 	ns, err := cluster.GetWatchNamespace()
 	if err != nil {
@@ -264,6 +266,11 @@ func main() {
 			setupLog.Error(err, "failed to create webhook")
 			os.Exit(1)
 		}
+
+		if err = webhook.RegisterDefaultingWebHook(mgr); err != nil {
+			setupLog.Error(err, "failed to create defaulting webhook")
+			os.Exit(1)
+		}
 	}
 
 	if metricsCertWatcher != nil {
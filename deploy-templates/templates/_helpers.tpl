@@ -60,3 +60,23 @@ Create the name of the service account to use
 {{- default "default" .Values.serviceAccount.name }}
 {{- end }}
 {{- end }}
+
+{{/*
+Create the name of the Capsule Tenant for the Stage namespaces
+*/}}
+{{- define "cd-pipeline-operator.capsuleTenantName" -}}
+{{- default (printf "edp-workload-%s" .Release.Namespace) .Values.capsuleTenant.name }}
+{{- end }}
+
+{{/*
+Create the name of the HNC parent namespace for the Stage subnamespaces
+*/}}
+{{- define "cd-pipeline-operator.hncParentNamespace" -}}
+{{- if eq .Values.tenancyEngine "hnc" }}
+{{- $parent := required "hnc.parentNamespace is required for tenancyEngine: hnc" .Values.hnc.parentNamespace }}
+{{- if eq $parent .Release.Namespace }}
+{{- fail "hnc.parentNamespace must be a dedicated namespace, not the release namespace" }}
+{{- end }}
+{{- $parent }}
+{{- end }}
+{{- end }}
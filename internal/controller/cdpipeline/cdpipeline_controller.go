@@ -185,7 +185,7 @@ func (r *ReconcileCDPipeline) setFinishStatus(ctx context.Context, p *cdPipeApi.
 		Status:          consts.FinishedStatus,
 		Available:       true,
 		LastTimeUpdated: metaV1.Now(),
-		Username:        "system",
+		Username:        cdPipeApi.LastModifiedBy(p),
 		Action:          cdPipeApi.SetupInitialStructureForCDPipeline,
 		Result:          cdPipeApi.Success,
 		Value:           "active",
@@ -207,7 +207,7 @@ func (r *ReconcileCDPipeline) setFailedStatus(ctx context.Context, p *cdPipeApi.
 		Status:          consts.FailedStatus,
 		Available:       false,
 		LastTimeUpdated: metaV1.Now(),
-		Username:        "system",
+		Username:        cdPipeApi.LastModifiedBy(p),
 		Result:          cdPipeApi.Error,
 		DetailedMessage: err.Error(),
 		Value:           consts.FailedStatus,
@@ -236,6 +236,8 @@ func (r *ReconcileCDPipeline) hasActiveOwnedStages(ctx context.Context, pipeline
 	return len(stages.Items) > 0, nil
 }
 
+// applyDefaults sets default values to the CDPipeline.
+// Default values are set by the defaulting webhook, it is used only if webhooks are disabled.
 func (r *ReconcileCDPipeline) applyDefaults(ctx context.Context, pipeline *cdPipeApi.CDPipeline) error {
 	if pipeline.Spec.ApplicationsToPromote == nil {
 		// currently it is not possible to set default as empty slice in the CRD definition by controller-gen
@@ -31,17 +31,14 @@ import (
 )
 
 const (
-	// nolint:gosec // Cluster secret label.
-	integrationSecretTypeLabel   = "app.edp.epam.com/secret-type"
-	integrationSecretTypeCluster = "cluster"
+	integrationSecretTypeLabel   = multiclusterclient.ClusterSecretTypeLabel
+	integrationSecretTypeCluster = multiclusterclient.ClusterSecretTypeCluster
 	clusterTypeLabel             = "app.edp.epam.com/cluster-type"
 	clusterTypeBearer            = "bearer"
 	clusterTypeIRSA              = "irsa"
 
-	// nolint:gosec // Cluster secret annotation.
-	clusterSecretConnectionAnnotation = "app.edp.epam.com/cluster-connected"
-	// nolint:gosec // Cluster secret annotation.
-	clusterSecretErrorAnnotation = "app.edp.epam.com/cluster-error"
+	clusterSecretConnectionAnnotation = multiclusterclient.ClusterSecretConnectionAnnotation
+	clusterSecretErrorAnnotation      = multiclusterclient.ClusterSecretErrorAnnotation
 
 	// Generated kubeconfig secret will be updated after 10 minutes.
 	// Generated token is valid for 15 minutes.
@@ -104,6 +101,8 @@ func (r *ReconcileClusterSecret) SetupWithManager(mgr ctrl.Manager) error {
 // - app.edp.epam.com/cluster-type=bearer - secret contains kubeconfig and should be converted to ArgoCD cluster secret.
 // - app.edp.epam.com/cluster-type=irsa - secret contains AWS IRSA configuration and should be converted to kubeconfig.
 // - if not specified - secret will be treated as bearer secret.
+// ArgoCD cluster secret can be scoped to namespaces and ArgoCD project
+// using app.edp.epam.com/argocd-cluster-* annotations of the secret.
 func (r *ReconcileClusterSecret) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	l := ctrl.LoggerFrom(ctx)
 
@@ -151,6 +150,11 @@ func (r *ReconcileClusterSecret) createArgoCDClusterSecret(ctx context.Context,
 		return fmt.Errorf("failed to convert cluster secret to rest config: %w", err)
 	}
 
+	scope, err := argocd.ClusterScopeFromSecret(secret)
+	if err != nil {
+		return fmt.Errorf("failed to get ArgoCD cluster scope: %w", err)
+	}
+
 	if err = r.checkClusterConnection(ctx, restConf); err != nil {
 		return err
 	}
@@ -176,14 +180,14 @@ func (r *ReconcileClusterSecret) createArgoCDClusterSecret(ctx context.Context,
 			return fmt.Errorf("failed to marshal cluster config: %w", err)
 		}
 
-		argocd.AddClusterLabel(argoClusterSecret)
-
 		argoClusterSecret.Data = map[string][]byte{
 			"name":   []byte(secret.Name),
 			"server": []byte(restConf.Host),
 			"config": rawConf,
 		}
 
+		argocd.ApplyClusterScope(argoClusterSecret, scope)
+
 		if metav1.GetControllerOfNoCopy(argoClusterSecret) != nil {
 			return nil
 		}
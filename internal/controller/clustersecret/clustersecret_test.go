@@ -128,6 +128,60 @@ var _ = Describe("secret with kube config", func() {
 			})
 		})
 	})
+	When("secret contains ArgoCD cluster scope annotations", func() {
+		var clusterSecretName = "cluster-secret-with-argocd-scope"
+
+		BeforeEach(func() {
+			By("creating cluster secret")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterSecretName,
+					Namespace: "default",
+					Labels: map[string]string{
+						clusterTypeLabel:           clusterTypeBearer,
+						integrationSecretTypeLabel: integrationSecretTypeCluster,
+					},
+					Annotations: map[string]string{
+						argocd.ClusterNamespacesAnnotation: "team-a-dev,team-a-qa",
+						argocd.ClusterProjectAnnotation:    "team-a",
+						argocd.ClusterLabelsAnnotation:     `{"region":"eu-central-1"}`,
+					},
+				},
+				Data: map[string][]byte{
+					"config": rawKubeConfig,
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).ToNot(HaveOccurred())
+		})
+		AfterEach(func() {
+			for _, name := range []string{clusterSecretName, clusterSecretNameToArgocdSecretName(clusterSecretName)} {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: "default",
+					},
+				}
+				err := ctrlclient.IgnoreNotFound(k8sClient.Delete(ctx, secret))
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+		It("should create scoped argocd secret", func() {
+			Eventually(func(g Gomega) {
+				argoSecret := &corev1.Secret{}
+				err := k8sClient.Get(ctx, ctrlclient.ObjectKey{
+					Name:      clusterSecretNameToArgocdSecretName(clusterSecretName),
+					Namespace: "default",
+				}, argoSecret)
+
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(string(argoSecret.Data["namespaces"])).Should(Equal("team-a-dev,team-a-qa"))
+				g.Expect(string(argoSecret.Data["clusterResources"])).Should(Equal("false"))
+				g.Expect(string(argoSecret.Data["project"])).Should(Equal("team-a"))
+				g.Expect(argoSecret.GetLabels()).Should(HaveKeyWithValue(argocd.ClusterLabel, argocd.ClusterLabelVal))
+				g.Expect(argoSecret.GetLabels()).Should(HaveKeyWithValue("region", "eu-central-1"))
+			}).Should(Succeed())
+		})
+	})
 	When("secret contains invalid kube config", func() {
 		var clusterSecretName = "cluster-secret-with-invalid-kube-config"
 
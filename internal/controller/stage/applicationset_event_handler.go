@@ -0,0 +1,95 @@
+package stage
+
+import (
+	"context"
+	"slices"
+
+	argoApi "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/argocd"
+)
+
+var _ handler.EventHandler = &ApplicationSetEventHandler{}
+
+// ApplicationSetEventHandler is a handler for ApplicationSet events,
+// which triggers reconciliation of the stages whose generator elements are changed.
+// It keeps the Stage environment metadata in sync with the deployed images.
+type ApplicationSetEventHandler struct {
+	client client.Client
+	log    logr.Logger
+}
+
+// NewApplicationSetEventHandler creates a new ApplicationSetEventHandler.
+func NewApplicationSetEventHandler(c client.Client, log logr.Logger) *ApplicationSetEventHandler {
+	return &ApplicationSetEventHandler{client: c, log: log}
+}
+
+// nolint
+// Create does nothing, skip event.
+func (h *ApplicationSetEventHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+// Update triggers stages with changed generator elements.
+func (h *ApplicationSetEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	oldAppset, ok := evt.ObjectOld.(*argoApi.ApplicationSet)
+	if !ok {
+		h.log.Info("Old object is not ApplicationSet")
+		return
+	}
+
+	newAppset, ok := evt.ObjectNew.(*argoApi.ApplicationSet)
+	if !ok {
+		h.log.Info("New object is not ApplicationSet")
+		return
+	}
+
+	changed := argocd.ChangedStages(oldAppset, newAppset)
+	if len(changed) == 0 {
+		return
+	}
+
+	stages := &cdPipeApi.StageList{}
+	if err := h.client.List(
+		ctx,
+		stages,
+		client.InNamespace(newAppset.Namespace),
+		client.MatchingLabels{cdPipeApi.StageCdPipelineLabelName: newAppset.Name},
+		client.Limit(clientLimit),
+	); err != nil {
+		h.log.Error(err, "unable to get stages for ApplicationSet", "application set", newAppset.Name)
+		return
+	}
+
+	for i := range stages.Items {
+		if !slices.Contains(changed, stages.Items[i].Spec.Name) {
+			continue
+		}
+
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: stages.Items[i].GetNamespace(),
+			Name:      stages.Items[i].GetName(),
+		}})
+	}
+}
+
+// nolint
+// Delete does nothing, skip event.
+func (h *ApplicationSetEventHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+// nolint
+// Generic does nothing, skip event.
+func (h *ApplicationSetEventHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
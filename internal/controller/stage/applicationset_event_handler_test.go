@@ -0,0 +1,125 @@
+package stage
+
+import (
+	"testing"
+
+	argoApi "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+func TestApplicationSetEventHandler_Update(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, cdPipeApi.AddToScheme(scheme))
+
+	newAppset := func(elements ...string) *argoApi.ApplicationSet {
+		raw := make([]apiextensionsv1.JSON, 0, len(elements))
+		for _, el := range elements {
+			raw = append(raw, apiextensionsv1.JSON{Raw: []byte(el)})
+		}
+
+		return &argoApi.ApplicationSet{
+			ObjectMeta: metaV1.ObjectMeta{
+				Namespace: "default",
+				Name:      "pipe",
+			},
+			Spec: argoApi.ApplicationSetSpec{
+				Generators: []argoApi.ApplicationSetGenerator{
+					{List: &argoApi.ListGenerator{Elements: raw}},
+				},
+			},
+		}
+	}
+
+	newStage := func(pipeline, name string) *cdPipeApi.Stage {
+		return &cdPipeApi.Stage{
+			ObjectMeta: metaV1.ObjectMeta{
+				Namespace: "default",
+				Name:      pipeline + "-" + name,
+				Labels:    map[string]string{cdPipeApi.StageCdPipelineLabelName: pipeline},
+			},
+			Spec: cdPipeApi.StageSpec{
+				Name:       name,
+				CdPipeline: pipeline,
+			},
+		}
+	}
+
+	stages := []client.Object{
+		newStage("pipe", "dev"),
+		newStage("pipe", "qa"),
+		newStage("other", "dev"),
+	}
+
+	tests := []struct {
+		name   string
+		evt    event.UpdateEvent
+		expLen int
+	}{
+		{
+			name: "should add stage with changed image to queue",
+			evt: event.UpdateEvent{
+				ObjectOld: newAppset(
+					`{"stage":"dev","codebase":"app","imageTag":"NaN"}`,
+					`{"stage":"qa","codebase":"app","imageTag":"NaN"}`,
+				),
+				ObjectNew: newAppset(
+					`{"stage":"dev","codebase":"app","imageTag":"1.0.0"}`,
+					`{"stage":"qa","codebase":"app","imageTag":"NaN"}`,
+				),
+			},
+			expLen: 1,
+		},
+		{
+			name: "should add stage with removed elements to queue",
+			evt: event.UpdateEvent{
+				ObjectOld: newAppset(
+					`{"stage":"dev","codebase":"app","imageTag":"NaN"}`,
+					`{"stage":"qa","codebase":"app","imageTag":"NaN"}`,
+				),
+				ObjectNew: newAppset(`{"stage":"dev","codebase":"app","imageTag":"NaN"}`),
+			},
+			expLen: 1,
+		},
+		{
+			name: "generator elements are not changed",
+			evt: event.UpdateEvent{
+				ObjectOld: newAppset(`{"stage":"dev","codebase":"app","imageTag":"NaN"}`),
+				ObjectNew: newAppset(`{"stage":"dev","codebase":"app","imageTag":"NaN"}`),
+			},
+			expLen: 0,
+		},
+		{
+			name:   "empty update event object",
+			evt:    event.UpdateEvent{},
+			expLen: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewApplicationSetEventHandler(
+				fake.NewClientBuilder().WithScheme(scheme).WithObjects(stages...).Build(),
+				logr.Discard(),
+			)
+
+			q := &controllertest.Queue{TypedInterface: workqueue.NewTyped[reconcile.Request]()}
+
+			h.Update(t.Context(), tt.evt, q)
+
+			assert.Equal(t, tt.expLen, q.Len())
+		})
+	}
+}
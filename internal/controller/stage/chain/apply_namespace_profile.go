@@ -0,0 +1,279 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/namespaceprofile"
+)
+
+// ApplyNamespaceProfile is a stage chain element that applies the namespace profile to the Stage namespace.
+type ApplyNamespaceProfile struct {
+	multiClusterClient multiClusterClient
+	internalClient     client.Client
+}
+
+// ServeRequest applies labels, annotations, ResourceQuota, LimitRange and NetworkPolicy from the profile.
+// Resources that are not defined in the profile anymore are deleted.
+// If the profile is not set, everything applied from the previous profile is removed.
+func (h ApplyNamespaceProfile) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
+	logger := ctrl.LoggerFrom(ctx).WithValues("namespace-profile", stage.Spec.NamespaceProfile)
+
+	profile, err := h.getProfile(ctx, stage)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Applying namespace profile")
+
+	if err = h.patchNamespace(ctx, stage.Spec.Namespace, profile); err != nil {
+		return err
+	}
+
+	if err = h.applyResourceQuota(ctx, stage.Spec.Namespace, profile); err != nil {
+		return err
+	}
+
+	if err = h.applyLimitRange(ctx, stage.Spec.Namespace, profile); err != nil {
+		return err
+	}
+
+	if err = h.applyNetworkPolicy(ctx, stage.Spec.Namespace, profile); err != nil {
+		return err
+	}
+
+	logger.Info("Namespace profile has been applied")
+
+	return nil
+}
+
+// getProfile returns the namespace profile of the Stage or an empty profile if it is not set.
+func (h ApplyNamespaceProfile) getProfile(
+	ctx context.Context,
+	stage *cdPipeApi.Stage,
+) (*namespaceprofile.Profile, error) {
+	if stage.Spec.NamespaceProfile == "" {
+		return &namespaceprofile.Profile{}, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := h.internalClient.Get(ctx, client.ObjectKey{
+		Name:      stage.Spec.NamespaceProfile,
+		Namespace: stage.Namespace,
+	}, cm); err != nil {
+		return nil, fmt.Errorf("failed to get namespace profile %s: %w", stage.Spec.NamespaceProfile, err)
+	}
+
+	profile, err := namespaceprofile.FromConfigMap(cm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse namespace profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// patchNamespace sets labels and annotations from the profile to the namespace.
+// Keys that are set from the profile are recorded in the namespace annotations,
+// so the ones dropped from the profile are removed.
+func (h ApplyNamespaceProfile) patchNamespace(
+	ctx context.Context,
+	namespace string,
+	profile *namespaceprofile.Profile,
+) error {
+	ns := &corev1.Namespace{}
+	if err := h.multiClusterClient.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		// The empty profile only removes what has been applied, so there is nothing to do without the namespace.
+		if apierrors.IsNotFound(err) && profile.Name == "" {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+
+	original := ns.DeepCopy()
+	patch := client.MergeFrom(original)
+
+	ns.Labels = applyManagedKeys(
+		ns.Labels,
+		profile.Labels,
+		ns.Annotations[namespaceprofile.ManagedLabelsAnnotation],
+	)
+	ns.Annotations = applyManagedKeys(
+		ns.Annotations,
+		profile.Annotations,
+		ns.Annotations[namespaceprofile.ManagedAnnotationsAnnotation],
+	)
+
+	setManagedKeys(ns.Annotations, namespaceprofile.ManagedLabelsAnnotation, profile.Labels)
+	setManagedKeys(ns.Annotations, namespaceprofile.ManagedAnnotationsAnnotation, profile.Annotations)
+
+	if maps.Equal(original.Labels, ns.Labels) && maps.Equal(original.Annotations, ns.Annotations) {
+		return nil
+	}
+
+	if err := h.multiClusterClient.Patch(ctx, ns, patch); err != nil {
+		return fmt.Errorf("failed to patch namespace %s: %w", namespace, err)
+	}
+
+	return nil
+}
+
+// applyManagedKeys removes the previously managed keys that are not desired anymore
+// and sets the desired keys. Managed is a comma-separated list of the keys set earlier.
+func applyManagedKeys(current, desired map[string]string, managed string) map[string]string {
+	result := maps.Clone(current)
+	if result == nil {
+		result = make(map[string]string, len(desired))
+	}
+
+	for _, k := range strings.Split(managed, ",") {
+		if _, ok := desired[k]; !ok {
+			delete(result, k)
+		}
+	}
+
+	maps.Copy(result, desired)
+
+	return result
+}
+
+// setManagedKeys stores the sorted keys in the annotation, so they can be removed later.
+func setManagedKeys(annotations map[string]string, annotation string, keys map[string]string) {
+	if len(keys) == 0 {
+		delete(annotations, annotation)
+
+		return
+	}
+
+	annotations[annotation] = strings.Join(slices.Sorted(maps.Keys(keys)), ",")
+}
+
+func (h ApplyNamespaceProfile) applyResourceQuota(
+	ctx context.Context,
+	namespace string,
+	profile *namespaceprofile.Profile,
+) error {
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      namespaceprofile.ResourceName,
+			Namespace: namespace,
+		},
+	}
+
+	if profile.ResourceQuota == nil {
+		return h.deleteObject(ctx, quota)
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, h.multiClusterClient, quota, func() error {
+		setProfileLabel(quota, profile)
+		quota.Spec = *profile.ResourceQuota
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to apply ResourceQuota: %w", err)
+	}
+
+	return nil
+}
+
+func (h ApplyNamespaceProfile) applyLimitRange(
+	ctx context.Context,
+	namespace string,
+	profile *namespaceprofile.Profile,
+) error {
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      namespaceprofile.ResourceName,
+			Namespace: namespace,
+		},
+	}
+
+	if profile.LimitRange == nil {
+		return h.deleteObject(ctx, limitRange)
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, h.multiClusterClient, limitRange, func() error {
+		setProfileLabel(limitRange, profile)
+		limitRange.Spec = *profile.LimitRange
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to apply LimitRange: %w", err)
+	}
+
+	return nil
+}
+
+func (h ApplyNamespaceProfile) applyNetworkPolicy(
+	ctx context.Context,
+	namespace string,
+	profile *namespaceprofile.Profile,
+) error {
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      namespaceprofile.DefaultDenyNetworkPolicyName,
+			Namespace: namespace,
+		},
+	}
+
+	if !profile.DefaultDenyNetworkPolicy {
+		return h.deleteObject(ctx, policy)
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, h.multiClusterClient, policy, func() error {
+		setProfileLabel(policy, profile)
+		policy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metaV1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to apply NetworkPolicy: %w", err)
+	}
+
+	return nil
+}
+
+// deleteObject deletes the object only if it was created from a namespace profile.
+func (h ApplyNamespaceProfile) deleteObject(ctx context.Context, obj client.Object) error {
+	if err := h.multiClusterClient.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get %s: %w", obj.GetName(), err)
+	}
+
+	if _, ok := obj.GetLabels()[namespaceprofile.ProfileLabel]; !ok {
+		return nil
+	}
+
+	if err := h.multiClusterClient.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete %s: %w", obj.GetName(), err)
+	}
+
+	return nil
+}
+
+func setProfileLabel(obj client.Object, profile *namespaceprofile.Profile) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+
+	labels[namespaceprofile.ProfileLabel] = profile.Name
+	obj.SetLabels(labels)
+}
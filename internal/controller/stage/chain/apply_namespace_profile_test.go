@@ -0,0 +1,347 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/namespaceprofile"
+)
+
+func TestApplyNamespaceProfile_ServeRequest(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, networkingv1.AddToScheme(scheme))
+
+	newStage := func(profile string) *cdPipeApi.Stage {
+		return &cdPipeApi.Stage{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      "stage",
+				Namespace: "default",
+			},
+			Spec: cdPipeApi.StageSpec{
+				Namespace:        "default-dev",
+				NamespaceProfile: profile,
+			},
+		}
+	}
+
+	targetNamespace := &corev1.Namespace{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name: "default-dev",
+			Labels: map[string]string{
+				"app.edp.epam.com/tenant": "default",
+			},
+		},
+	}
+
+	profileLabels := map[string]string{
+		namespaceprofile.ProfileLabel: "restricted",
+	}
+
+	tests := []struct {
+		name           string
+		stage          *cdPipeApi.Stage
+		internalObjs   []client.Object
+		clusterObjects []client.Object
+		wantErr        require.ErrorAssertionFunc
+		want           func(t *testing.T, cl client.Client)
+	}{
+		{
+			name:           "profile is applied",
+			stage:          newStage("restricted"),
+			clusterObjects: []client.Object{targetNamespace.DeepCopy()},
+			internalObjs: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "restricted",
+						Namespace: "default",
+					},
+					Data: map[string]string{
+						namespaceprofile.LabelsKey:                   "team: backend",
+						namespaceprofile.AnnotationsKey:              "owner: backend",
+						namespaceprofile.ResourceQuotaKey:            "hard: {pods: \"10\"}",
+						namespaceprofile.LimitRangeKey:               "limits: [{type: Container}]",
+						namespaceprofile.DefaultDenyNetworkPolicyKey: "true",
+						namespaceprofile.PodSecurityEnforceKey:       "restricted",
+					},
+				},
+			},
+			wantErr: require.NoError,
+			want: func(t *testing.T, cl client.Client) {
+				ns := &corev1.Namespace{}
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{Name: "default-dev"}, ns))
+				assert.Equal(t, map[string]string{
+					"app.edp.epam.com/tenant":            "default",
+					"team":                               "backend",
+					"pod-security.kubernetes.io/enforce": "restricted",
+				}, ns.Labels)
+				assert.Equal(t, map[string]string{
+					"owner":                                  "backend",
+					namespaceprofile.ManagedLabelsAnnotation: "pod-security.kubernetes.io/enforce,team",
+					namespaceprofile.ManagedAnnotationsAnnotation: "owner",
+				}, ns.Annotations)
+
+				quota := &corev1.ResourceQuota{}
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Name:      namespaceprofile.ResourceName,
+					Namespace: "default-dev",
+				}, quota))
+				assert.Equal(t, "restricted", quota.Labels[namespaceprofile.ProfileLabel])
+				assert.Equal(t, "10", quota.Spec.Hard.Pods().String())
+
+				limitRange := &corev1.LimitRange{}
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Name:      namespaceprofile.ResourceName,
+					Namespace: "default-dev",
+				}, limitRange))
+				assert.Len(t, limitRange.Spec.Limits, 1)
+
+				policy := &networkingv1.NetworkPolicy{}
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Name:      namespaceprofile.DefaultDenyNetworkPolicyName,
+					Namespace: "default-dev",
+				}, policy))
+				assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, policy.Spec.PolicyTypes)
+				assert.Empty(t, policy.Spec.Ingress)
+			},
+		},
+		{
+			name:  "labels and annotations removed from profile are deleted",
+			stage: newStage("restricted"),
+			clusterObjects: []client.Object{
+				&corev1.Namespace{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name: "default-dev",
+						Labels: map[string]string{
+							"app.edp.epam.com/tenant":            "default",
+							"team":                               "backend",
+							"pod-security.kubernetes.io/enforce": "restricted",
+						},
+						Annotations: map[string]string{
+							"owner":                                  "backend",
+							"custom":                                 "value",
+							namespaceprofile.ManagedLabelsAnnotation: "pod-security.kubernetes.io/enforce,team",
+							namespaceprofile.ManagedAnnotationsAnnotation: "owner",
+						},
+					},
+				},
+			},
+			internalObjs: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "restricted",
+						Namespace: "default",
+					},
+					Data: map[string]string{
+						namespaceprofile.LabelsKey: "team: frontend",
+					},
+				},
+			},
+			wantErr: require.NoError,
+			want: func(t *testing.T, cl client.Client) {
+				ns := &corev1.Namespace{}
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{Name: "default-dev"}, ns))
+				assert.Equal(t, map[string]string{
+					"app.edp.epam.com/tenant": "default",
+					"team":                    "frontend",
+				}, ns.Labels)
+				assert.Equal(t, map[string]string{
+					"custom":                                 "value",
+					namespaceprofile.ManagedLabelsAnnotation: "team",
+				}, ns.Annotations)
+			},
+		},
+		{
+			name:  "resources removed from profile are deleted",
+			stage: newStage("restricted"),
+			clusterObjects: []client.Object{
+				targetNamespace.DeepCopy(),
+				&corev1.ResourceQuota{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      namespaceprofile.ResourceName,
+						Namespace: "default-dev",
+						Labels:    profileLabels,
+					},
+				},
+				&networkingv1.NetworkPolicy{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      namespaceprofile.DefaultDenyNetworkPolicyName,
+						Namespace: "default-dev",
+						Labels:    profileLabels,
+					},
+				},
+				&corev1.LimitRange{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      namespaceprofile.ResourceName,
+						Namespace: "default-dev",
+					},
+				},
+			},
+			internalObjs: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "restricted",
+						Namespace: "default",
+					},
+				},
+			},
+			wantErr: require.NoError,
+			want: func(t *testing.T, cl client.Client) {
+				err := cl.Get(context.Background(), client.ObjectKey{
+					Name:      namespaceprofile.ResourceName,
+					Namespace: "default-dev",
+				}, &corev1.ResourceQuota{})
+				assert.True(t, k8sErrors.IsNotFound(err))
+
+				err = cl.Get(context.Background(), client.ObjectKey{
+					Name:      namespaceprofile.DefaultDenyNetworkPolicyName,
+					Namespace: "default-dev",
+				}, &networkingv1.NetworkPolicy{})
+				assert.True(t, k8sErrors.IsNotFound(err))
+
+				// LimitRange wasn't created from the profile, so it is kept.
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Name:      namespaceprofile.ResourceName,
+					Namespace: "default-dev",
+				}, &corev1.LimitRange{}))
+			},
+		},
+		{
+			name:           "profile is not set",
+			stage:          newStage(""),
+			clusterObjects: []client.Object{targetNamespace.DeepCopy()},
+			wantErr:        require.NoError,
+			want: func(t *testing.T, cl client.Client) {
+				quotas := &corev1.ResourceQuotaList{}
+				require.NoError(t, cl.List(context.Background(), quotas))
+				assert.Empty(t, quotas.Items)
+			},
+		},
+		{
+			name:  "previously applied profile is removed when profile is cleared",
+			stage: newStage(""),
+			clusterObjects: []client.Object{
+				&corev1.Namespace{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name: "default-dev",
+						Labels: map[string]string{
+							"app.edp.epam.com/tenant":            "default",
+							"team":                               "backend",
+							"pod-security.kubernetes.io/enforce": "restricted",
+						},
+						Annotations: map[string]string{
+							"owner":                                  "backend",
+							"custom":                                 "value",
+							namespaceprofile.ManagedLabelsAnnotation: "pod-security.kubernetes.io/enforce,team",
+							namespaceprofile.ManagedAnnotationsAnnotation: "owner",
+						},
+					},
+				},
+				&corev1.ResourceQuota{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      namespaceprofile.ResourceName,
+						Namespace: "default-dev",
+						Labels:    profileLabels,
+					},
+				},
+				&corev1.LimitRange{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      namespaceprofile.ResourceName,
+						Namespace: "default-dev",
+						Labels:    profileLabels,
+					},
+				},
+				&networkingv1.NetworkPolicy{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      namespaceprofile.DefaultDenyNetworkPolicyName,
+						Namespace: "default-dev",
+						Labels:    profileLabels,
+					},
+				},
+			},
+			wantErr: require.NoError,
+			want: func(t *testing.T, cl client.Client) {
+				ns := &corev1.Namespace{}
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{Name: "default-dev"}, ns))
+				assert.Equal(t, map[string]string{"app.edp.epam.com/tenant": "default"}, ns.Labels)
+				assert.Equal(t, map[string]string{"custom": "value"}, ns.Annotations)
+
+				key := client.ObjectKey{Name: namespaceprofile.ResourceName, Namespace: "default-dev"}
+				assert.True(t, k8sErrors.IsNotFound(cl.Get(context.Background(), key, &corev1.ResourceQuota{})))
+				assert.True(t, k8sErrors.IsNotFound(cl.Get(context.Background(), key, &corev1.LimitRange{})))
+				assert.True(t, k8sErrors.IsNotFound(cl.Get(context.Background(), client.ObjectKey{
+					Name:      namespaceprofile.DefaultDenyNetworkPolicyName,
+					Namespace: "default-dev",
+				}, &networkingv1.NetworkPolicy{})))
+			},
+		},
+		{
+			name:    "profile is not set and namespace doesn't exist",
+			stage:   newStage(""),
+			wantErr: require.NoError,
+			want:    func(t *testing.T, cl client.Client) {},
+		},
+		{
+			name:           "profile doesn't exist",
+			stage:          newStage("restricted"),
+			clusterObjects: []client.Object{targetNamespace.DeepCopy()},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to get namespace profile restricted")
+			},
+			want: func(t *testing.T, cl client.Client) {},
+		},
+		{
+			name:  "namespace doesn't exist",
+			stage: newStage("restricted"),
+			internalObjs: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "restricted",
+						Namespace: "default",
+					},
+					Data: map[string]string{
+						namespaceprofile.LabelsKey: "team: backend",
+					},
+				},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to get namespace default-dev")
+			},
+			want: func(t *testing.T, cl client.Client) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clusterClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.clusterObjects...).Build()
+			h := ApplyNamespaceProfile{
+				multiClusterClient: clusterClient,
+				internalClient:     fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.internalObjs...).Build(),
+			}
+
+			err := h.ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), tt.stage)
+
+			tt.wantErr(t, err)
+			tt.want(t, clusterClient)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	rbacApi "k8s.io/api/rbac/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
@@ -29,24 +30,25 @@ func (h ConfigureRegistryViewerRbac) ServeRequest(ctx context.Context, stage *cd
 		return nil
 	}
 
-	if err := h.rbac.CreateRoleBindingIfNotExists(
-		ctx,
-		roleBindingName,
-		stage.Namespace,
-		[]rbacApi.Subject{
+	if err := h.rbac.ApplyRoleBinding(ctx, &rbacApi.RoleBinding{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      roleBindingName,
+			Namespace: stage.Namespace,
+		},
+		Subjects: []rbacApi.Subject{
 			{
 				Kind:     rbacApi.GroupKind,
 				APIGroup: rbacApi.GroupName,
 				Name:     fmt.Sprintf("system:serviceaccounts:%s", targetNamespace),
 			},
 		},
-		rbacApi.RoleRef{
+		RoleRef: rbacApi.RoleRef{
 			Kind:     rbac.ClusterRoleKind,
 			APIGroup: rbacApi.GroupName,
 			Name:     "registry-viewer",
 		},
-	); err != nil {
-		return fmt.Errorf("failed to create %s RoleBinding: %w", roleBindingName, err)
+	}); err != nil {
+		return fmt.Errorf("failed to apply %s RoleBinding: %w", roleBindingName, err)
 	}
 
 	logger.Info("RoleBinding sa-registry-viewer has been configured")
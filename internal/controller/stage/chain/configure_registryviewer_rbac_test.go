@@ -85,6 +85,53 @@ func TestConfigureRegistryViewerRbac_ServeRequest(t *testing.T) {
 				}, &rbacApi.RoleBinding{}))
 			},
 		},
+		{
+			name: "rbac drift is corrected",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "test-stage",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace: "stage-ns",
+				},
+			},
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.TypeEnv, platform.Openshift)
+			},
+			objects: []runtime.Object{
+				&rbacApi.RoleBinding{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "sa-registry-viewer-stage-ns",
+						Namespace: namespace,
+					},
+					Subjects: []rbacApi.Subject{
+						{
+							Kind:     rbacApi.GroupKind,
+							APIGroup: rbacApi.GroupName,
+							Name:     "system:serviceaccounts:other-ns",
+						},
+					},
+					RoleRef: rbacApi.RoleRef{
+						Kind:     rbac.ClusterRoleKind,
+						APIGroup: rbacApi.GroupName,
+						Name:     "registry-viewer",
+					},
+				},
+			},
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, stage *cdPipeApi.Stage, k8sClient client.Client) {
+				rb := &rbacApi.RoleBinding{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Name:      generateSaRegistryViewerRoleBindingName(stage),
+					Namespace: stage.Namespace,
+				}, rb))
+
+				require.Len(t, rb.Subjects, 1)
+				require.Equal(t, "system:serviceaccounts:stage-ns", rb.Subjects[0].Name)
+				require.Equal(t, rbac.ManagedByValue, rb.Labels[rbac.ManagedByLabel])
+			},
+		},
 		{
 			name: "skip rbac configuration for kubernetes",
 			stage: &cdPipeApi.Stage{
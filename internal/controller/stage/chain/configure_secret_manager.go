@@ -2,8 +2,12 @@ package chain
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"os"
+	"slices"
+	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	rbacApi "k8s.io/api/rbac/v1"
@@ -12,9 +16,12 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/externalsecrets"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/rbac"
 )
 
@@ -22,10 +29,16 @@ const (
 	externalSecretIntegrationRoleName   = "external-secret-integration"
 	secretIntegrationServiceAccountName = "secret-manager"
 	secretStoreName                     = "edp-system"
-	externalSecretName                  = "regcred"
-	secretManagerEnv                    = "SECRET_MANAGER"
-	secretManagerESO                    = "eso"
-	secretManagerOwn                    = "own"
+	secretManagerEnv                    = platform.SecretManagerEnv
+	secretManagerESO                    = platform.SecretManagerESO
+	secretManagerOwn                    = platform.SecretManagerOwn
+	defaultServiceAccountName           = "default"
+
+	// secretHashAnnotation is a hash of the source Secret content the copy is synced with.
+	secretHashAnnotation = "app.edp.epam.com/secret-hash"
+	// imagePullSecretsAnnotation is a list of the image pull secrets attached to the default ServiceAccount
+	// by the operator. It is used to detach and delete the secrets removed from the configuration.
+	imagePullSecretsAnnotation = "app.edp.epam.com/image-pull-secrets"
 )
 
 // ConfigureSecretManager is a stage chain element that configures secret management.
@@ -36,19 +49,31 @@ type ConfigureSecretManager struct {
 
 // ServeRequest implements the logic to configure secret management.
 func (h ConfigureSecretManager) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
-	secretManager := os.Getenv(secretManagerEnv)
+	secretManager, err := util.GetSecretManager(ctx, h.internalClient, stage)
+	if err != nil {
+		return err
+	}
+
 	logger := ctrl.LoggerFrom(ctx).WithValues(
 		"target-ns", stage.Spec.Namespace,
 		"secret-manager", secretManager,
 	)
 
+	if isHNCSubnamespace(stage) {
+		logger.Info("Secrets are propagated from the parent namespace by HNC, skipping")
+
+		return nil
+	}
+
+	secrets := util.GetImagePullSecrets(stage)
+
 	switch secretManager {
 	case secretManagerESO:
-		if err := h.configureEso(ctrl.LoggerInto(ctx, logger), stage); err != nil {
+		if err := h.configureEso(ctrl.LoggerInto(ctx, logger), stage, secrets); err != nil {
 			return err
 		}
 	case secretManagerOwn:
-		if err := h.configureOwn(ctrl.LoggerInto(ctx, logger), stage); err != nil {
+		if err := h.configureOwn(ctrl.LoggerInto(ctx, logger), stage, secrets); err != nil {
 			return err
 		}
 	default:
@@ -56,14 +81,38 @@ func (h ConfigureSecretManager) ServeRequest(ctx context.Context, stage *cdPipeA
 		return nil
 	}
 
-	return nil
+	serviceAccount, err := h.getDefaultServiceAccount(ctx, stage.Spec.Namespace)
+	if err != nil {
+		return err
+	}
+
+	stale := staleImagePullSecrets(serviceAccount, secrets)
+	if err = h.pruneImagePullSecrets(ctrl.LoggerInto(ctx, logger), stage, secretManager, stale); err != nil {
+		return err
+	}
+
+	return h.attachImagePullSecrets(ctrl.LoggerInto(ctx, logger), stage.Spec.Namespace, serviceAccount, secrets)
 }
 
-func (h ConfigureSecretManager) configureEso(ctx context.Context, stage *cdPipeApi.Stage) error {
+func (h ConfigureSecretManager) configureEso(ctx context.Context, stage *cdPipeApi.Stage, secrets []string) error {
 	logger := ctrl.LoggerFrom(ctx)
 
 	logger.Info("Configuring external secret integration")
 
+	storeTemplate, err := getSecretStoreTemplate(ctx, h.internalClient, stage)
+	if err != nil {
+		return err
+	}
+
+	if storeTemplate != nil {
+		return h.configureEsoFromTemplate(
+			ctrl.LoggerInto(ctx, logger.WithValues("secret-store-template", storeTemplate.Name)),
+			stage,
+			storeTemplate,
+			secrets,
+		)
+	}
+
 	externalSecretIntegrationRole := &rbacApi.Role{}
 	if err := h.multiClusterClient.Get(ctx, client.ObjectKey{
 		Name:      "external-secret-integration",
@@ -97,12 +146,15 @@ func (h ConfigureSecretManager) configureEso(ctx context.Context, stage *cdPipeA
 		return err
 	}
 
-	if _, err = h.createExternalSecret(
-		ctrl.LoggerInto(ctx, logger),
-		stage.Spec.Namespace,
-		secretStore.GetName(),
-	); err != nil {
-		return err
+	for _, secret := range secrets {
+		if _, err = h.createExternalSecret(
+			ctrl.LoggerInto(ctx, logger),
+			stage.Spec.Namespace,
+			secretStore.GetName(),
+			secret,
+		); err != nil {
+			return err
+		}
 	}
 
 	logger.Info("External secret integration has been configured successfully")
@@ -110,41 +162,439 @@ func (h ConfigureSecretManager) configureEso(ctx context.Context, stage *cdPipeA
 	return nil
 }
 
-func (h ConfigureSecretManager) configureOwn(ctx context.Context, stage *cdPipeApi.Stage) error {
+// getSecretStoreTemplate returns the rendered SecretStore template of the Stage.
+// It returns nil if the Stage doesn't reference the template and the tenant doesn't have the default one.
+func getSecretStoreTemplate(
+	ctx context.Context,
+	internalClient client.Client,
+	stage *cdPipeApi.Stage,
+) (*externalsecrets.Template, error) {
+	name := util.GetSecretStoreTemplateName(stage)
+
+	cm := &corev1.ConfigMap{}
+	if err := internalClient.Get(ctx, client.ObjectKey{
+		Namespace: stage.Namespace,
+		Name:      name,
+	}, cm); err != nil {
+		if k8sErrors.IsNotFound(err) && stage.Spec.SecretStoreTemplate == "" {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get SecretStore template %s: %w", name, err)
+	}
+
+	storeTemplate, err := externalsecrets.RenderTemplate(cm, externalsecrets.TemplateData{
+		Tenant:     stage.Namespace,
+		Name:       stage.Name,
+		CDPipeline: stage.Spec.CdPipeline,
+		Stage:      stage.Spec.Name,
+		Namespace:  stage.Spec.Namespace,
+		Cluster:    stage.Spec.ClusterName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render SecretStore template: %w", err)
+	}
+
+	return storeTemplate, nil
+}
+
+// configureEsoFromTemplate creates the SecretStore and ExternalSecrets declared in the SecretStore template.
+// The resources are updated if the template is changed.
+func (h ConfigureSecretManager) configureEsoFromTemplate(
+	ctx context.Context,
+	stage *cdPipeApi.Stage,
+	storeTemplate *externalsecrets.Template,
+	secrets []string,
+) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	secretStore := externalsecrets.NewSecretStore(secretStoreName, stage.Spec.Namespace)
+	if err := h.applySpec(ctx, secretStore, storeTemplate.SecretStoreSpec); err != nil {
+		return fmt.Errorf("failed to apply %s secret store: %w", secretStoreName, err)
+	}
+
+	externalSecrets := storeTemplate.ExternalSecrets
+	if len(externalSecrets) == 0 {
+		externalSecrets = make([]externalsecrets.ExternalSecretTemplate, 0, len(secrets))
+
+		for _, secret := range secrets {
+			externalSecrets = append(externalSecrets, externalsecrets.ExternalSecretTemplate{
+				Name: secret,
+				Spec: imagePullExternalSecretSpec(secretStoreName, secret),
+			})
+		}
+	}
+
+	for _, es := range externalSecrets {
+		spec := es.Spec
+		if spec == nil {
+			spec = map[string]interface{}{}
+		}
+
+		if _, ok := spec["secretStoreRef"]; !ok {
+			spec["secretStoreRef"] = map[string]interface{}{
+				"kind": externalsecrets.SecretStoreKind,
+				"name": secretStoreName,
+			}
+		}
+
+		externalSecret := externalsecrets.NewExternalSecret(es.Name, stage.Spec.Namespace)
+		if err := h.applySpec(ctx, externalSecret, spec); err != nil {
+			return fmt.Errorf("failed to apply %s external secret: %w", es.Name, err)
+		}
+	}
+
+	logger.Info("External secret integration has been configured from the template")
+
+	return nil
+}
+
+// applySpec creates the object with the given spec or updates the spec of the existing object.
+func (h ConfigureSecretManager) applySpec(
+	ctx context.Context,
+	obj *unstructured.Unstructured,
+	spec map[string]interface{},
+) error {
+	if _, err := controllerutil.CreateOrUpdate(ctx, h.multiClusterClient, obj, func() error {
+		obj.Object["spec"] = spec
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to create or update %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	return nil
+}
+
+func (h ConfigureSecretManager) configureOwn(ctx context.Context, stage *cdPipeApi.Stage, secrets []string) error {
 	logger := ctrl.LoggerFrom(ctx)
 
 	logger.Info("Configuring own secrets management")
 
-	recred := &corev1.Secret{}
+	for _, secret := range secrets {
+		if err := h.syncSecret(ctx, stage, secret); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Own secrets management has been configured successfully")
+
+	return nil
+}
+
+// syncSecret copies the Secret from the Stage namespace to the Stage target namespace.
+// An existing Secret without the hash annotation isn't a copy made by the operator, so it is kept as is.
+func (h ConfigureSecretManager) syncSecret(ctx context.Context, stage *cdPipeApi.Stage, name string) error {
+	logger := ctrl.LoggerFrom(ctx).WithValues("secret", name)
+
+	source := &corev1.Secret{}
 	if err := h.internalClient.Get(ctx, client.ObjectKey{
 		Namespace: stage.Namespace,
-		Name:      externalSecretName,
-	}, recred); err != nil {
-		return fmt.Errorf("failed to get %s secret: %w", externalSecretName, err)
+		Name:      name,
+	}, source); err != nil {
+		return fmt.Errorf("failed to get %s secret: %w", name, err)
 	}
 
-	externalRecred := &corev1.Secret{
-		ObjectMeta: metaV1.ObjectMeta{
-			Name:      externalSecretName,
-			Namespace: stage.Spec.Namespace,
-		},
-		Type: recred.Type,
-		Data: recred.Data,
+	hash := secretContentHash(source)
+
+	secretCopy := &corev1.Secret{}
+
+	err := h.multiClusterClient.Get(ctx, client.ObjectKey{
+		Namespace: stage.Spec.Namespace,
+		Name:      name,
+	}, secretCopy)
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get %s secret copy: %w", name, err)
 	}
 
-	if err := h.multiClusterClient.Create(ctx, externalRecred); err != nil {
-		if !k8sErrors.IsAlreadyExists(err) {
-			return fmt.Errorf("failed to create %s secret: %w", externalSecretName, err)
+	if k8sErrors.IsNotFound(err) {
+		if err = h.multiClusterClient.Create(ctx, newSecretCopy(source, stage.Spec.Namespace, hash)); err != nil {
+			return fmt.Errorf("failed to create %s secret: %w", name, err)
 		}
 
-		logger.Info(fmt.Sprintf("Secret %s already exists", externalSecretName))
+		logger.Info("Secret has been created")
+
+		return nil
 	}
 
-	logger.Info("Own secrets management has been configured successfully")
+	// Secrets created by others, e.g. by a user, are not adopted, so they are never changed or deleted by the operator.
+	if _, ok := secretCopy.Annotations[secretHashAnnotation]; !ok {
+		logger.Info("Secret already exists and isn't managed by the operator, skipping")
+
+		return nil
+	}
+
+	// The copy is updated if the source is rotated or the copy is changed in the target namespace.
+	if secretCopy.Annotations[secretHashAnnotation] == hash && secretContentHash(secretCopy) == hash {
+		logger.Info("Secret is up to date")
+
+		return nil
+	}
+
+	if secretCopy.Type != source.Type {
+		// Secret type is immutable, so the copy is recreated.
+		if err = h.multiClusterClient.Delete(ctx, secretCopy); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete outdated %s secret: %w", name, err)
+		}
+
+		if err = h.multiClusterClient.Create(ctx, newSecretCopy(source, stage.Spec.Namespace, hash)); err != nil {
+			return fmt.Errorf("failed to recreate %s secret: %w", name, err)
+		}
+	} else {
+		if secretCopy.Annotations == nil {
+			secretCopy.Annotations = make(map[string]string, 1)
+		}
+
+		secretCopy.Annotations[secretHashAnnotation] = hash
+		secretCopy.Data = source.Data
+
+		if err = h.multiClusterClient.Update(ctx, secretCopy); err != nil {
+			return fmt.Errorf("failed to update %s secret: %w", name, err)
+		}
+	}
+
+	logger.Info("Secret has been synced")
+
+	return nil
+}
+
+// getDefaultServiceAccount returns the default ServiceAccount of the namespace or nil if it doesn't exist yet.
+func (h ConfigureSecretManager) getDefaultServiceAccount(
+	ctx context.Context,
+	namespace string,
+) (*corev1.ServiceAccount, error) {
+	serviceAccount := &corev1.ServiceAccount{}
+
+	if err := h.multiClusterClient.Get(ctx, client.ObjectKey{
+		Namespace: namespace,
+		Name:      defaultServiceAccountName,
+	}, serviceAccount); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get %s service account: %w", defaultServiceAccountName, err)
+	}
+
+	return serviceAccount, nil
+}
+
+// pruneImagePullSecrets deletes the image pull secrets that were removed from the configuration.
+// Only the objects created by the operator are deleted: copies in the own mode
+// and ExternalSecrets created for the image pull secrets in the eso mode.
+func (h ConfigureSecretManager) pruneImagePullSecrets(
+	ctx context.Context,
+	stage *cdPipeApi.Stage,
+	secretManager string,
+	stale []string,
+) error {
+	if len(stale) == 0 {
+		return nil
+	}
+
+	logger := ctrl.LoggerFrom(ctx)
+
+	if secretManager == secretManagerESO {
+		storeTemplate, err := getSecretStoreTemplate(ctx, h.internalClient, stage)
+		if err != nil {
+			return err
+		}
+
+		// ExternalSecrets declared in the template are managed by the template.
+		if storeTemplate != nil && len(storeTemplate.ExternalSecrets) > 0 {
+			return nil
+		}
+	}
+
+	for _, name := range stale {
+		if secretManager == secretManagerESO {
+			if err := h.multiClusterClient.Delete(
+				ctx,
+				externalsecrets.NewExternalSecret(name, stage.Spec.Namespace),
+			); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete %s external secret: %w", name, err)
+			}
+
+			logger.Info("External secret of the removed image pull secret has been deleted", "secret", name)
+
+			continue
+		}
+
+		secretCopy := &corev1.Secret{}
+
+		err := h.multiClusterClient.Get(ctx, client.ObjectKey{Namespace: stage.Spec.Namespace, Name: name}, secretCopy)
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				continue
+			}
+
+			return fmt.Errorf("failed to get %s secret copy: %w", name, err)
+		}
+
+		// The Secret with the same name can be created by a user, so only the copy is deleted.
+		if _, ok := secretCopy.Annotations[secretHashAnnotation]; !ok {
+			continue
+		}
+
+		if err = h.multiClusterClient.Delete(ctx, secretCopy); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete %s secret copy: %w", name, err)
+		}
+
+		logger.Info("Copy of the removed image pull secret has been deleted", "secret", name)
+	}
 
 	return nil
 }
 
+// attachImagePullSecrets sets the image pull secrets to the default ServiceAccount of the namespace,
+// so workloads pull images without referencing the secrets explicitly.
+// The secrets attached by the operator earlier and removed from the configuration are detached.
+func (h ConfigureSecretManager) attachImagePullSecrets(
+	ctx context.Context,
+	namespace string,
+	serviceAccount *corev1.ServiceAccount,
+	secrets []string,
+) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	if serviceAccount == nil {
+		// Kubernetes creates the default ServiceAccount asynchronously after the namespace creation.
+		serviceAccount = &corev1.ServiceAccount{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      defaultServiceAccountName,
+				Namespace: namespace,
+			},
+		}
+		setImagePullSecrets(serviceAccount, secrets)
+
+		if err := h.multiClusterClient.Create(ctx, serviceAccount); err != nil {
+			return fmt.Errorf("failed to create %s service account: %w", defaultServiceAccountName, err)
+		}
+
+		logger.Info("Image pull secrets have been attached to the default service account")
+
+		return nil
+	}
+
+	patch := client.MergeFrom(serviceAccount.DeepCopy())
+
+	if !setImagePullSecrets(serviceAccount, secrets) {
+		return nil
+	}
+
+	if err := h.multiClusterClient.Patch(ctx, serviceAccount, patch); err != nil {
+		return fmt.Errorf("failed to attach image pull secrets to %s service account: %w", defaultServiceAccountName, err)
+	}
+
+	logger.Info("Image pull secrets have been attached to the default service account")
+
+	return nil
+}
+
+// staleImagePullSecrets returns the image pull secrets attached to the ServiceAccount by the operator
+// that are not in the given secrets anymore.
+func staleImagePullSecrets(serviceAccount *corev1.ServiceAccount, secrets []string) []string {
+	if serviceAccount == nil {
+		return nil
+	}
+
+	var stale []string
+
+	for _, name := range strings.Split(serviceAccount.Annotations[imagePullSecretsAnnotation], ",") {
+		if name != "" && !slices.Contains(secrets, name) {
+			stale = append(stale, name)
+		}
+	}
+
+	return stale
+}
+
+// setImagePullSecrets adds the missing secrets to the ServiceAccount image pull secrets
+// and removes the stale ones attached by the operator. Secrets attached by others are kept.
+// It returns true if the ServiceAccount is changed.
+func setImagePullSecrets(serviceAccount *corev1.ServiceAccount, secrets []string) bool {
+	stale := staleImagePullSecrets(serviceAccount, secrets)
+	changed := false
+
+	serviceAccount.ImagePullSecrets = slices.DeleteFunc(
+		serviceAccount.ImagePullSecrets,
+		func(ref corev1.LocalObjectReference) bool {
+			if slices.Contains(stale, ref.Name) {
+				changed = true
+
+				return true
+			}
+
+			return false
+		},
+	)
+
+	for _, secret := range secrets {
+		if slices.ContainsFunc(serviceAccount.ImagePullSecrets, func(ref corev1.LocalObjectReference) bool {
+			return ref.Name == secret
+		}) {
+			continue
+		}
+
+		serviceAccount.ImagePullSecrets = append(serviceAccount.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+		changed = true
+	}
+
+	managed := strings.Join(secrets, ",")
+	if serviceAccount.Annotations[imagePullSecretsAnnotation] == managed {
+		return changed
+	}
+
+	if managed == "" {
+		delete(serviceAccount.Annotations, imagePullSecretsAnnotation)
+	} else {
+		if serviceAccount.Annotations == nil {
+			serviceAccount.Annotations = make(map[string]string, 1)
+		}
+
+		serviceAccount.Annotations[imagePullSecretsAnnotation] = managed
+	}
+
+	return true
+}
+
+// newSecretCopy creates a copy of the Secret in the given namespace.
+func newSecretCopy(source *corev1.Secret, namespace, hash string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      source.Name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				secretHashAnnotation: hash,
+			},
+		},
+		Type: source.Type,
+		Data: source.Data,
+	}
+}
+
+// secretContentHash returns a hash of the Secret type and data.
+func secretContentHash(secret *corev1.Secret) string {
+	keys := make([]string, 0, len(secret.Data))
+	for k := range secret.Data {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	h := sha256.New()
+	h.Write([]byte(secret.Type))
+
+	for _, k := range keys {
+		h.Write([]byte{0})
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write(secret.Data[k])
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func (h ConfigureSecretManager) createServiceAccount(
 	ctx context.Context,
 	namespace string,
@@ -250,22 +700,39 @@ func (h ConfigureSecretManager) createSecretStore(
 func (h ConfigureSecretManager) createExternalSecret(
 	ctx context.Context,
 	stageTargetNamespace,
-	secretStoreName string,
+	secretStoreName,
+	secretName string,
 ) (*unstructured.Unstructured, error) {
 	l := ctrl.LoggerFrom(ctx)
 
-	externalSecret := externalsecrets.NewExternalSecret(externalSecretName, stageTargetNamespace)
-	externalSecret.Object["spec"] = map[string]interface{}{
+	externalSecret := externalsecrets.NewExternalSecret(secretName, stageTargetNamespace)
+	externalSecret.Object["spec"] = imagePullExternalSecretSpec(secretStoreName, secretName)
+
+	if err := h.multiClusterClient.Create(ctx, externalSecret); err != nil {
+		if !k8sErrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create %s external secret: %w", externalSecret.GetName(), err)
+		}
+
+		l.Info("External secret for external secret integration already exists")
+	}
+
+	return externalSecret, nil
+}
+
+// imagePullExternalSecretSpec returns the spec of the ExternalSecret that creates the image pull secret
+// from the dockerconfigjson of the remote secret with the same name.
+func imagePullExternalSecretSpec(storeName, secretName string) map[string]interface{} {
+	return map[string]interface{}{
 		"refreshInterval": "1h",
 		"secretStoreRef": map[string]interface{}{
 			"kind": externalsecrets.SecretStoreKind,
-			"name": secretStoreName,
+			"name": storeName,
 		},
 		"data": []interface{}{
 			map[string]interface{}{
 				"secretKey": "secretValue",
 				"remoteRef": map[string]interface{}{
-					"key":                "regcred",
+					"key":                secretName,
 					"property":           ".dockerconfigjson",
 					"decodingStrategy":   "None",
 					"conversionStrategy": "Default",
@@ -284,14 +751,4 @@ func (h ConfigureSecretManager) createExternalSecret(
 			},
 		},
 	}
-
-	if err := h.multiClusterClient.Create(ctx, externalSecret); err != nil {
-		if !k8sErrors.IsAlreadyExists(err) {
-			return nil, fmt.Errorf("failed to create %s external secret: %w", externalSecret.GetName(), err)
-		}
-
-		l.Info("External secret for external secret integration already exists")
-	}
-
-	return externalSecret, nil
 }
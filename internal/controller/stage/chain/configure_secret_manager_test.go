@@ -9,7 +9,9 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	rbacApi "k8s.io/api/rbac/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -17,6 +19,7 @@ import (
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/externalsecrets"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
 )
 
 func TestConfigureManageSecretsRBAC_ServeRequest(t *testing.T) {
@@ -75,16 +78,198 @@ func TestConfigureManageSecretsRBAC_ServeRequest(t *testing.T) {
 
 				require.NoError(t, err)
 
-				externalSecret := externalsecrets.NewExternalSecret(externalSecretName, stage.Spec.Namespace)
+				externalSecret := externalsecrets.NewExternalSecret(platform.DefaultImagePullSecret, stage.Spec.Namespace)
 				err = cl.Get(context.Background(), client.ObjectKey{
 					Namespace: stage.Spec.Namespace,
-					Name:      externalSecretName,
+					Name:      platform.DefaultImagePullSecret,
 				}, externalSecret)
 
 				require.NoError(t, err)
 			},
 			wantErr: require.NoError,
 		},
+		{
+			name: "eso - image pull secrets from configuration are synced",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace: "test-namespace",
+				},
+			},
+			objects: []client.Object{
+				&rbacApi.Role{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      externalSecretIntegrationRoleName,
+						Namespace: "default",
+					},
+				},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerESO)
+				t.Setenv(platform.ImagePullSecrets, "regcred,quay-creds")
+			},
+			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {
+				for _, name := range []string{"regcred", "quay-creds"} {
+					require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+						Namespace: stage.Spec.Namespace,
+						Name:      name,
+					}, externalsecrets.NewExternalSecret(name, stage.Spec.Namespace)))
+				}
+
+				serviceAccount := &corev1.ServiceAccount{}
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      defaultServiceAccountName,
+				}, serviceAccount))
+				require.Equal(t, []corev1.LocalObjectReference{
+					{Name: "regcred"},
+					{Name: "quay-creds"},
+				}, serviceAccount.ImagePullSecrets)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "eso - secret store is created from the stage template",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace:           "test-namespace",
+					SecretStoreTemplate: "vault",
+				},
+			},
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "vault",
+						Namespace: "default",
+					},
+					Data: map[string]string{
+						externalsecrets.SecretStoreKey: `
+provider:
+  vault:
+    server: https://vault.example.com
+    path: "{{ .Namespace }}"
+`,
+						externalsecrets.ExternalSecretsKey: `
+- name: app-db
+  spec:
+    dataFrom:
+    - extract:
+        key: "{{ .Tenant }}/db"
+`,
+					},
+				},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerESO)
+			},
+			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {
+				secretStore := externalsecrets.NewSecretStore(secretStoreName, stage.Spec.Namespace)
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      secretStoreName,
+				}, secretStore))
+
+				path, _, err := unstructured.NestedString(secretStore.Object, "spec", "provider", "vault", "path")
+				require.NoError(t, err)
+				require.Equal(t, "test-namespace", path)
+
+				externalSecret := externalsecrets.NewExternalSecret("app-db", stage.Spec.Namespace)
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      "app-db",
+				}, externalSecret))
+
+				storeRef, _, err := unstructured.NestedString(externalSecret.Object, "spec", "secretStoreRef", "name")
+				require.NoError(t, err)
+				require.Equal(t, secretStoreName, storeRef)
+
+				err = cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      secretIntegrationServiceAccountName,
+				}, &corev1.ServiceAccount{})
+				require.True(t, k8sErrors.IsNotFound(err))
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "eso - secret store is updated from the tenant template",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace:   "test-namespace",
+					ClusterName: "prod-cluster",
+				},
+			},
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      externalsecrets.DefaultTemplateName,
+						Namespace: "default",
+					},
+					Data: map[string]string{
+						externalsecrets.SecretStoreKey: `
+provider:
+  aws:
+    service: SecretsManager
+    region: "{{ .Cluster }}"
+`,
+					},
+				},
+				externalsecrets.NewSecretStore(secretStoreName, "test-namespace"),
+			},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerESO)
+			},
+			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {
+				secretStore := externalsecrets.NewSecretStore(secretStoreName, stage.Spec.Namespace)
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      secretStoreName,
+				}, secretStore))
+
+				region, _, err := unstructured.NestedString(secretStore.Object, "spec", "provider", "aws", "region")
+				require.NoError(t, err)
+				require.Equal(t, "prod-cluster", region)
+
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      platform.DefaultImagePullSecret,
+				}, externalsecrets.NewExternalSecret(platform.DefaultImagePullSecret, stage.Spec.Namespace)))
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "eso - secret store template doesn't exist",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace:           "test-namespace",
+					SecretStoreTemplate: "vault",
+				},
+			},
+			objects: []client.Object{},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerESO)
+			},
+			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to get SecretStore template vault")
+			},
+		},
 		{
 			name: "secretManagerESO all objects already exist",
 			stage: &cdPipeApi.Stage{
@@ -116,7 +301,7 @@ func TestConfigureManageSecretsRBAC_ServeRequest(t *testing.T) {
 					},
 				},
 				externalsecrets.NewSecretStore(secretStoreName, "test-namespace"),
-				externalsecrets.NewExternalSecret(externalSecretName, "test-namespace"),
+				externalsecrets.NewExternalSecret(platform.DefaultImagePullSecret, "test-namespace"),
 			},
 			setup: func(t *testing.T) {
 				t.Setenv(secretManagerEnv, secretManagerESO)
@@ -195,7 +380,7 @@ func TestConfigureManageSecretsRBAC_ServeRequest(t *testing.T) {
 			objects: []client.Object{
 				&corev1.Secret{
 					ObjectMeta: metaV1.ObjectMeta{
-						Name:      externalSecretName,
+						Name:      platform.DefaultImagePullSecret,
 						Namespace: "default",
 					},
 					Data: map[string][]byte{
@@ -210,7 +395,7 @@ func TestConfigureManageSecretsRBAC_ServeRequest(t *testing.T) {
 				secret := &corev1.Secret{}
 				err := cl.Get(context.Background(), client.ObjectKey{
 					Namespace: stage.Spec.Namespace,
-					Name:      externalSecretName,
+					Name:      platform.DefaultImagePullSecret,
 				}, secret)
 
 				require.NoError(t, err)
@@ -232,7 +417,7 @@ func TestConfigureManageSecretsRBAC_ServeRequest(t *testing.T) {
 			objects: []client.Object{
 				&corev1.Secret{
 					ObjectMeta: metaV1.ObjectMeta{
-						Name:      externalSecretName,
+						Name:      platform.DefaultImagePullSecret,
 						Namespace: "default",
 					},
 					Data: map[string][]byte{
@@ -241,7 +426,7 @@ func TestConfigureManageSecretsRBAC_ServeRequest(t *testing.T) {
 				},
 				&corev1.Secret{
 					ObjectMeta: metaV1.ObjectMeta{
-						Name:      externalSecretName,
+						Name:      platform.DefaultImagePullSecret,
 						Namespace: "test-namespace",
 					},
 					Data: map[string][]byte{
@@ -256,7 +441,7 @@ func TestConfigureManageSecretsRBAC_ServeRequest(t *testing.T) {
 				secret := &corev1.Secret{}
 				err := cl.Get(context.Background(), client.ObjectKey{
 					Namespace: stage.Spec.Namespace,
-					Name:      externalSecretName,
+					Name:      platform.DefaultImagePullSecret,
 				}, secret)
 
 				require.NoError(t, err)
@@ -264,6 +449,452 @@ func TestConfigureManageSecretsRBAC_ServeRequest(t *testing.T) {
 			},
 			wantErr: require.NoError,
 		},
+		{
+			name: "own secret manager - secret created by user is not adopted",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace: "test-namespace",
+				},
+			},
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      platform.DefaultImagePullSecret,
+						Namespace: "default",
+					},
+					Data: map[string][]byte{
+						"test": []byte("source"),
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      platform.DefaultImagePullSecret,
+						Namespace: "test-namespace",
+					},
+					Data: map[string][]byte{
+						"test": []byte("user"),
+					},
+				},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerOwn)
+			},
+			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {
+				secret := &corev1.Secret{}
+				err := cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      platform.DefaultImagePullSecret,
+				}, secret)
+
+				require.NoError(t, err)
+				require.Equal(t, map[string][]byte{"test": []byte("user")}, secret.Data)
+				require.NotContains(t, secret.Annotations, secretHashAnnotation)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "own secret manager - source secret is rotated",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace: "test-namespace",
+				},
+			},
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      platform.DefaultImagePullSecret,
+						Namespace: "default",
+					},
+					Data: map[string][]byte{
+						"test": []byte("new"),
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      platform.DefaultImagePullSecret,
+						Namespace: "test-namespace",
+						Annotations: map[string]string{
+							secretHashAnnotation: secretContentHash(&corev1.Secret{
+								Data: map[string][]byte{"test": []byte("old")},
+							}),
+						},
+					},
+					Data: map[string][]byte{
+						"test": []byte("old"),
+					},
+				},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerOwn)
+			},
+			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {
+				secret := &corev1.Secret{}
+				err := cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      platform.DefaultImagePullSecret,
+				}, secret)
+
+				require.NoError(t, err)
+				require.Equal(t, map[string][]byte{"test": []byte("new")}, secret.Data)
+				require.Equal(t, secretContentHash(secret), secret.Annotations[secretHashAnnotation])
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "own secret manager - secret copy is changed in the target namespace",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace: "test-namespace",
+				},
+			},
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      platform.DefaultImagePullSecret,
+						Namespace: "default",
+					},
+					Type: corev1.SecretTypeDockerConfigJson,
+					Data: map[string][]byte{
+						corev1.DockerConfigJsonKey: []byte("{}"),
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      platform.DefaultImagePullSecret,
+						Namespace: "test-namespace",
+						Annotations: map[string]string{
+							secretHashAnnotation: secretContentHash(&corev1.Secret{
+								Type: corev1.SecretTypeDockerConfigJson,
+								Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+							}),
+						},
+					},
+					Type: corev1.SecretTypeOpaque,
+					Data: map[string][]byte{
+						"test": []byte("changed"),
+					},
+				},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerOwn)
+			},
+			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {
+				secret := &corev1.Secret{}
+				err := cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      platform.DefaultImagePullSecret,
+				}, secret)
+
+				require.NoError(t, err)
+				require.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+				require.Equal(t, map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")}, secret.Data)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "own secret manager - stage image pull secrets are synced",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace:        "test-namespace",
+					ImagePullSecrets: []string{"regcred", "quay-creds"},
+				},
+			},
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "regcred",
+						Namespace: "default",
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "quay-creds",
+						Namespace: "default",
+					},
+				},
+				&corev1.ServiceAccount{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      defaultServiceAccountName,
+						Namespace: "test-namespace",
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "default-dockercfg"}},
+				},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerOwn)
+			},
+			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {
+				for _, name := range []string{"regcred", "quay-creds"} {
+					require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+						Namespace: stage.Spec.Namespace,
+						Name:      name,
+					}, &corev1.Secret{}))
+				}
+
+				serviceAccount := &corev1.ServiceAccount{}
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      defaultServiceAccountName,
+				}, serviceAccount))
+				require.Equal(t, []corev1.LocalObjectReference{
+					{Name: "default-dockercfg"},
+					{Name: "regcred"},
+					{Name: "quay-creds"},
+				}, serviceAccount.ImagePullSecrets)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "own secret manager - removed image pull secrets are pruned",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace:        "test-namespace",
+					ImagePullSecrets: []string{"regcred"},
+				},
+			},
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "regcred",
+						Namespace: "default",
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:        "quay-creds",
+						Namespace:   "test-namespace",
+						Annotations: map[string]string{secretHashAnnotation: "hash"},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "user-creds",
+						Namespace: "test-namespace",
+					},
+				},
+				&corev1.ServiceAccount{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:        defaultServiceAccountName,
+						Namespace:   "test-namespace",
+						Annotations: map[string]string{imagePullSecretsAnnotation: "regcred,quay-creds,user-creds"},
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{
+						{Name: "default-dockercfg"},
+						{Name: "regcred"},
+						{Name: "quay-creds"},
+						{Name: "user-creds"},
+					},
+				},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerOwn)
+			},
+			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {
+				err := cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      "quay-creds",
+				}, &corev1.Secret{})
+				require.True(t, k8sErrors.IsNotFound(err))
+
+				// The Secret wasn't copied by the operator, so it is only detached.
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      "user-creds",
+				}, &corev1.Secret{}))
+
+				serviceAccount := &corev1.ServiceAccount{}
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      defaultServiceAccountName,
+				}, serviceAccount))
+				require.Equal(t, []corev1.LocalObjectReference{
+					{Name: "default-dockercfg"},
+					{Name: "regcred"},
+				}, serviceAccount.ImagePullSecrets)
+				require.Equal(t, "regcred", serviceAccount.Annotations[imagePullSecretsAnnotation])
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "eso - removed image pull secrets are pruned",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace:        "test-namespace",
+					ImagePullSecrets: []string{"regcred"},
+				},
+			},
+			objects: []client.Object{
+				&rbacApi.Role{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      externalSecretIntegrationRoleName,
+						Namespace: "default",
+					},
+				},
+				externalsecrets.NewExternalSecret("quay-creds", "test-namespace"),
+				&corev1.ServiceAccount{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:        defaultServiceAccountName,
+						Namespace:   "test-namespace",
+						Annotations: map[string]string{imagePullSecretsAnnotation: "regcred,quay-creds"},
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "regcred"}, {Name: "quay-creds"}},
+				},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerESO)
+			},
+			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {
+				err := cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      "quay-creds",
+				}, externalsecrets.NewExternalSecret("quay-creds", stage.Spec.Namespace))
+				require.True(t, k8sErrors.IsNotFound(err))
+
+				serviceAccount := &corev1.ServiceAccount{}
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      defaultServiceAccountName,
+				}, serviceAccount))
+				require.Equal(t, []corev1.LocalObjectReference{{Name: "regcred"}}, serviceAccount.ImagePullSecrets)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "own secret manager is set in the tenant config",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace:  "test-namespace",
+					CdPipeline: "mypipeline",
+				},
+			},
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      platform.KrciConfigMap,
+						Namespace: "default",
+					},
+					Data: map[string]string{
+						platform.KrciConfigSecretManager: secretManagerOwn,
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      platform.DefaultImagePullSecret,
+						Namespace: "default",
+					},
+				},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerESO)
+			},
+			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      platform.DefaultImagePullSecret,
+				}, &corev1.Secret{}))
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "secret manager of CDPipeline is unknown",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace:  "test-namespace",
+					CdPipeline: "mypipeline",
+				},
+			},
+			objects: []client.Object{
+				&cdPipeApi.CDPipeline{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "mypipeline",
+						Namespace: "default",
+						Annotations: map[string]string{
+							cdPipeApi.SecretManagerAnnotation: "vault",
+						},
+					},
+				},
+			},
+			setup: func(t *testing.T) {},
+			want:  func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unknown secret manager \"vault\"")
+			},
+		},
+		{
+			name: "secret manager is disabled by CDPipeline",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace:  "test-namespace",
+					CdPipeline: "mypipeline",
+				},
+			},
+			objects: []client.Object{
+				&cdPipeApi.CDPipeline{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "mypipeline",
+						Namespace: "default",
+						Annotations: map[string]string{
+							cdPipeApi.SecretManagerAnnotation: "none",
+						},
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      platform.KrciConfigMap,
+						Namespace: "default",
+					},
+					Data: map[string]string{
+						platform.KrciConfigSecretManager: secretManagerOwn,
+					},
+				},
+			},
+			setup: func(t *testing.T) {},
+			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {
+				err := cl.Get(context.Background(), client.ObjectKey{
+					Namespace: stage.Spec.Namespace,
+					Name:      platform.DefaultImagePullSecret,
+				}, &corev1.Secret{})
+				require.True(t, k8sErrors.IsNotFound(err))
+			},
+			wantErr: require.NoError,
+		},
 		{
 			name: "own secret manager - failed to get secret to copy data from",
 			stage: &cdPipeApi.Stage{
@@ -282,7 +913,7 @@ func TestConfigureManageSecretsRBAC_ServeRequest(t *testing.T) {
 			want: func(t *testing.T, cl client.Client, stage *cdPipeApi.Stage) {},
 			wantErr: func(t require.TestingT, err error, i ...interface{}) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), fmt.Sprintf("failed to get %s secret", externalSecretName))
+				require.Contains(t, err.Error(), fmt.Sprintf("failed to get %s secret", platform.DefaultImagePullSecret))
 			},
 		},
 	}
@@ -0,0 +1,120 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+const (
+	// stageServiceAccountLabel is a label of the application ServiceAccounts created from the Stage spec.
+	stageServiceAccountLabel = "app.edp.epam.com/stage-service-account"
+	// stageServiceAccountLabelsAnnotation is a list of the ServiceAccount labels set from the Stage spec.
+	stageServiceAccountLabelsAnnotation = "app.edp.epam.com/stage-service-account-labels"
+	// stageServiceAccountAnnotationsAnnotation is a list of the ServiceAccount annotations set from the Stage spec.
+	stageServiceAccountAnnotationsAnnotation = "app.edp.epam.com/stage-service-account-annotations"
+)
+
+// ConfigureServiceAccounts is a stage chain element that creates application ServiceAccounts in the Stage namespace.
+type ConfigureServiceAccounts struct {
+	multiClusterClient multiClusterClient
+}
+
+// ServeRequest creates or updates the ServiceAccounts declared in the Stage
+// and removes the ServiceAccounts that are not declared anymore.
+func (h ConfigureServiceAccounts) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
+	logger := ctrl.LoggerFrom(ctx).WithValues("target-ns", stage.Spec.Namespace)
+
+	logger.Info("Configuring application ServiceAccounts")
+
+	declared := make(map[string]struct{}, len(stage.Spec.ServiceAccounts))
+
+	for i := range stage.Spec.ServiceAccounts {
+		if err := h.applyServiceAccount(ctx, stage.Spec.Namespace, &stage.Spec.ServiceAccounts[i]); err != nil {
+			return err
+		}
+
+		declared[stage.Spec.ServiceAccounts[i].Name] = struct{}{}
+	}
+
+	serviceAccounts := &corev1.ServiceAccountList{}
+	if err := h.multiClusterClient.List(
+		ctx,
+		serviceAccounts,
+		client.InNamespace(stage.Spec.Namespace),
+		client.HasLabels{stageServiceAccountLabel},
+	); err != nil {
+		return fmt.Errorf("failed to list application ServiceAccounts: %w", err)
+	}
+
+	for i := range serviceAccounts.Items {
+		name := serviceAccounts.Items[i].Name
+
+		// The default ServiceAccount is managed by Kubernetes, so it is never deleted.
+		if _, ok := declared[name]; ok || name == defaultServiceAccountName {
+			continue
+		}
+
+		if err := h.multiClusterClient.Delete(ctx, &serviceAccounts.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ServiceAccount %s: %w", name, err)
+		}
+
+		logger.Info("ServiceAccount has been deleted", "name", name)
+	}
+
+	logger.Info("Application ServiceAccounts have been configured")
+
+	return nil
+}
+
+func (h ConfigureServiceAccounts) applyServiceAccount(
+	ctx context.Context,
+	namespace string,
+	spec *cdPipeApi.StageServiceAccount,
+) error {
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      spec.Name,
+			Namespace: namespace,
+		},
+	}
+
+	res, err := controllerutil.CreateOrUpdate(ctx, h.multiClusterClient, serviceAccount, func() error {
+		// ServiceAccounts created by others are not adopted, so they are never changed or deleted by the operator.
+		if _, ok := serviceAccount.Labels[stageServiceAccountLabel]; !ok && serviceAccount.ResourceVersion != "" {
+			return fmt.Errorf("ServiceAccount %s already exists and isn't managed by the operator", spec.Name)
+		}
+
+		serviceAccount.Labels = applyManagedKeys(
+			serviceAccount.Labels,
+			spec.Labels,
+			serviceAccount.Annotations[stageServiceAccountLabelsAnnotation],
+		)
+		serviceAccount.Labels[stageServiceAccountLabel] = "true"
+
+		serviceAccount.Annotations = applyManagedKeys(
+			serviceAccount.Annotations,
+			spec.Annotations,
+			serviceAccount.Annotations[stageServiceAccountAnnotationsAnnotation],
+		)
+
+		setManagedKeys(serviceAccount.Annotations, stageServiceAccountLabelsAnnotation, spec.Labels)
+		setManagedKeys(serviceAccount.Annotations, stageServiceAccountAnnotationsAnnotation, spec.Annotations)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply ServiceAccount %s: %w", spec.Name, err)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("ServiceAccount has been applied", "name", spec.Name, "result", res)
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+func TestConfigureServiceAccounts_ServeRequest(t *testing.T) {
+	t.Parallel()
+
+	const (
+		namespace = "test-namespace"
+		roleArn   = "eks.amazonaws.com/role-arn"
+	)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	newStage := func(serviceAccounts ...cdPipeApi.StageServiceAccount) *cdPipeApi.Stage {
+		return &cdPipeApi.Stage{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      "stage-1",
+				Namespace: "default",
+			},
+			Spec: cdPipeApi.StageSpec{
+				Namespace:       namespace,
+				ServiceAccounts: serviceAccounts,
+			},
+		}
+	}
+
+	newServiceAccount := func(name string, labels, annotations map[string]string) *corev1.ServiceAccount {
+		return &corev1.ServiceAccount{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+		}
+	}
+
+	managed := map[string]string{stageServiceAccountLabel: "true"}
+
+	tests := []struct {
+		name      string
+		stage     *cdPipeApi.Stage
+		objects   []client.Object
+		wantErr   require.ErrorAssertionFunc
+		wantCheck func(t *testing.T, k8sClient client.Client)
+	}{
+		{
+			name: "service account is created",
+			stage: newStage(cdPipeApi.StageServiceAccount{
+				Name:        "app",
+				Annotations: map[string]string{roleArn: "arn:aws:iam::123456789012:role/app-dev"},
+				Labels:      map[string]string{"team": "backend"},
+			}),
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				sa := &corev1.ServiceAccount{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: namespace,
+					Name:      "app",
+				}, sa))
+
+				assert.Equal(t, "arn:aws:iam::123456789012:role/app-dev", sa.Annotations[roleArn])
+				assert.Equal(t, "backend", sa.Labels["team"])
+				assert.Equal(t, "true", sa.Labels[stageServiceAccountLabel])
+			},
+		},
+		{
+			name: "service account annotations are updated",
+			stage: newStage(cdPipeApi.StageServiceAccount{
+				Name:        "app",
+				Annotations: map[string]string{roleArn: "arn:aws:iam::123456789012:role/app-qa"},
+			}),
+			objects: []client.Object{
+				newServiceAccount("app", managed, map[string]string{
+					roleArn: "arn:aws:iam::123456789012:role/app-dev",
+					"other": "value",
+				}),
+			},
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				sa := &corev1.ServiceAccount{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: namespace,
+					Name:      "app",
+				}, sa))
+
+				assert.Equal(t, "arn:aws:iam::123456789012:role/app-qa", sa.Annotations[roleArn])
+				assert.Equal(t, "value", sa.Annotations["other"])
+			},
+		},
+		{
+			name: "labels and annotations removed from the spec are deleted",
+			stage: newStage(cdPipeApi.StageServiceAccount{
+				Name:   "app",
+				Labels: map[string]string{"team": "backend"},
+			}),
+			objects: []client.Object{
+				newServiceAccount(
+					"app",
+					map[string]string{stageServiceAccountLabel: "true", "team": "backend", "tier": "api"},
+					map[string]string{
+						roleArn:                                  "arn:aws:iam::123456789012:role/app-dev",
+						"other":                                  "value",
+						stageServiceAccountLabelsAnnotation:      "team,tier",
+						stageServiceAccountAnnotationsAnnotation: roleArn,
+					},
+				),
+			},
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				sa := &corev1.ServiceAccount{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: namespace,
+					Name:      "app",
+				}, sa))
+
+				assert.Equal(t, map[string]string{stageServiceAccountLabel: "true", "team": "backend"}, sa.Labels)
+				assert.Equal(t, map[string]string{
+					"other":                             "value",
+					stageServiceAccountLabelsAnnotation: "team",
+				}, sa.Annotations)
+			},
+		},
+		{
+			name: "existing service account is not adopted",
+			stage: newStage(cdPipeApi.StageServiceAccount{
+				Name:        "app",
+				Annotations: map[string]string{roleArn: "arn:aws:iam::123456789012:role/app-dev"},
+			}),
+			objects: []client.Object{
+				newServiceAccount("app", nil, nil),
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "ServiceAccount app already exists and isn't managed by the operator")
+			},
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				sa := &corev1.ServiceAccount{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: namespace,
+					Name:      "app",
+				}, sa))
+
+				assert.Empty(t, sa.Labels)
+				assert.Empty(t, sa.Annotations)
+			},
+		},
+		{
+			name:  "removed service accounts are deleted",
+			stage: newStage(cdPipeApi.StageServiceAccount{Name: "app"}),
+			objects: []client.Object{
+				newServiceAccount("app", managed, nil),
+				newServiceAccount("worker", managed, nil),
+				newServiceAccount(defaultServiceAccountName, managed, nil),
+				newServiceAccount("unmanaged", nil, nil),
+			},
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				serviceAccounts := &corev1.ServiceAccountList{}
+				require.NoError(t, k8sClient.List(context.Background(), serviceAccounts, client.InNamespace(namespace)))
+
+				names := make([]string, 0, len(serviceAccounts.Items))
+				for i := range serviceAccounts.Items {
+					names = append(names, serviceAccounts.Items[i].Name)
+				}
+
+				assert.ElementsMatch(t, []string{"app", defaultServiceAccountName, "unmanaged"}, names)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+
+			h := ConfigureServiceAccounts{
+				multiClusterClient: k8sClient,
+			}
+
+			err := h.ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), tt.stage)
+
+			tt.wantErr(t, err)
+			tt.wantCheck(t, k8sClient)
+		})
+	}
+}
@@ -2,10 +2,14 @@ package chain
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
 	rbacApi "k8s.io/api/rbac/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
@@ -14,6 +18,12 @@ import (
 
 const (
 	tenantAdminRbName = "tenant-admin"
+
+	// accessControlLabel is a label of the RoleBindings created from the Stage access control bindings.
+	// The value of the label is the name of the binding.
+	accessControlLabel = "app.edp.epam.com/access-control"
+
+	accessControlRbPrefix = "access-control-"
 )
 
 type ConfigureTenantAdminRbac struct {
@@ -23,13 +33,29 @@ type ConfigureTenantAdminRbac struct {
 func (h ConfigureTenantAdminRbac) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
 	targetNamespace := stage.Spec.Namespace
 	logger := ctrl.LoggerFrom(ctx).WithValues("target-ns", targetNamespace)
+
+	if len(stage.Spec.AccessControl) > 0 {
+		return h.configureAccessControl(ctx, stage)
+	}
+
+	if err := h.deleteStaleAccessControl(ctx, targetNamespace, nil); err != nil {
+		return err
+	}
+
+	if isHNCSubnamespace(stage) {
+		logger.Info("Tenant admin RBAC is propagated from the parent namespace by HNC, skipping")
+
+		return nil
+	}
+
 	logger.Info("Configuring tenant admin RBAC")
 
-	if err := h.rbac.CreateRoleBindingIfNotExists(
-		ctx,
-		tenantAdminRbName,
-		targetNamespace,
-		[]rbacApi.Subject{
+	if err := h.rbac.ApplyRoleBinding(ctx, &rbacApi.RoleBinding{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      tenantAdminRbName,
+			Namespace: targetNamespace,
+		},
+		Subjects: []rbacApi.Subject{
 			{
 				APIGroup: rbacApi.GroupName,
 				Kind:     rbacApi.GroupKind,
@@ -41,13 +67,13 @@ func (h ConfigureTenantAdminRbac) ServeRequest(ctx context.Context, stage *cdPip
 				Name:     GetOIDCDeveloperGroupName(stage.Namespace),
 			},
 		},
-		rbacApi.RoleRef{
+		RoleRef: rbacApi.RoleRef{
 			APIGroup: rbacApi.GroupName,
 			Kind:     rbac.ClusterRoleKind,
 			Name:     "admin",
 		},
-	); err != nil {
-		return fmt.Errorf("failed to create %s rolebinding: %w", tenantAdminRbName, err)
+	}); err != nil {
+		return fmt.Errorf("failed to apply %s rolebinding: %w", tenantAdminRbName, err)
 	}
 
 	logger.Info("RBAC for tenant admin has been configured successfully")
@@ -55,6 +81,106 @@ func (h ConfigureTenantAdminRbac) ServeRequest(ctx context.Context, stage *cdPip
 	return nil
 }
 
+// configureAccessControl applies RoleBindings of the Stage access control bindings
+// and removes the RoleBindings that are not declared in the Stage anymore.
+// The access control bindings replace the default tenant admin RoleBinding.
+func (h ConfigureTenantAdminRbac) configureAccessControl(ctx context.Context, stage *cdPipeApi.Stage) error {
+	targetNamespace := stage.Spec.Namespace
+	logger := ctrl.LoggerFrom(ctx).WithValues("target-ns", targetNamespace)
+
+	logger.Info("Configuring Stage access control")
+
+	// The webhook rejects such Stages, it is checked again in case the webhook is disabled.
+	if isHNCSubnamespace(stage) {
+		return errors.New("access control is not supported in HNC subnamespace, " +
+			"tenant RoleBindings are propagated from the parent namespace")
+	}
+
+	declared := make([]string, 0, len(stage.Spec.AccessControl))
+	allowedRoles := platform.GetAccessControlAllowedRoles()
+
+	for i := range stage.Spec.AccessControl {
+		// The webhook rejects such bindings, it is checked again in case the webhook is disabled.
+		if roleName := stage.Spec.AccessControl[i].RoleRef.Name; !slices.Contains(allowedRoles, roleName) {
+			return fmt.Errorf("role %s of access control binding %s is not allowed", roleName, stage.Spec.AccessControl[i].Name)
+		}
+
+		rb := newAccessControlRoleBinding(&stage.Spec.AccessControl[i], targetNamespace)
+
+		if err := h.rbac.ApplyRoleBinding(ctx, rb); err != nil {
+			return fmt.Errorf("failed to apply %s rolebinding: %w", rb.Name, err)
+		}
+
+		declared = append(declared, rb.Name)
+	}
+
+	if err := h.deleteStaleAccessControl(ctx, targetNamespace, declared); err != nil {
+		return err
+	}
+
+	if err := h.rbac.DeleteRoleBinding(ctx, tenantAdminRbName, targetNamespace); err != nil {
+		return fmt.Errorf("failed to delete %s rolebinding: %w", tenantAdminRbName, err)
+	}
+
+	logger.Info("Stage access control has been configured successfully")
+
+	return nil
+}
+
+// deleteStaleAccessControl deletes access control RoleBindings that are not in the declared list.
+func (h ConfigureTenantAdminRbac) deleteStaleAccessControl(
+	ctx context.Context,
+	namespace string,
+	declared []string,
+) error {
+	if err := h.rbac.DeleteStaleRoleBindings(ctx, namespace, declared, client.HasLabels{accessControlLabel}); err != nil {
+		return fmt.Errorf("failed to delete stale access control rolebindings: %w", err)
+	}
+
+	return nil
+}
+
+func newAccessControlRoleBinding(binding *cdPipeApi.AccessControlBinding, namespace string) *rbacApi.RoleBinding {
+	subjects := make([]rbacApi.Subject, 0, len(binding.Subjects))
+
+	for _, s := range binding.Subjects {
+		subject := rbacApi.Subject{
+			APIGroup: rbacApi.GroupName,
+			Kind:     s.Kind,
+			Name:     s.Name,
+		}
+
+		// Only ServiceAccounts of the Stage namespace can be bound.
+		if s.Kind == rbacApi.ServiceAccountKind {
+			subject.APIGroup = ""
+			subject.Namespace = namespace
+		}
+
+		subjects = append(subjects, subject)
+	}
+
+	roleKind := binding.RoleRef.Kind
+	if roleKind == "" {
+		roleKind = rbac.ClusterRoleKind
+	}
+
+	return &rbacApi.RoleBinding{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      accessControlRbPrefix + binding.Name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				accessControlLabel: binding.Name,
+			},
+		},
+		Subjects: subjects,
+		RoleRef: rbacApi.RoleRef{
+			APIGroup: rbacApi.GroupName,
+			Kind:     roleKind,
+			Name:     binding.RoleRef.Name,
+		},
+	}
+}
+
 // GetOIDCAdminGroupName returns the name of the OIDC admin group or a default one if not set.
 func GetOIDCAdminGroupName(stageNamespace string) string {
 	if group := platform.GetOIDCAdminGroupName(); group != "" {
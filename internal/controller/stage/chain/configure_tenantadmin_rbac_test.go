@@ -81,6 +81,52 @@ func TestConfigureTenantAdminRbac_ServeRequest(t *testing.T) {
 				}, &rbacApi.RoleBinding{}))
 			},
 		},
+		{
+			name: "rbac drift is corrected",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "test-stage",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace: "stage-1-ns",
+				},
+			},
+			objects: []runtime.Object{
+				&rbacApi.RoleBinding{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      tenantAdminRbName,
+						Namespace: "stage-1-ns",
+					},
+					Subjects: []rbacApi.Subject{
+						{
+							APIGroup: rbacApi.GroupName,
+							Kind:     rbacApi.GroupKind,
+							Name:     "old-admins",
+						},
+					},
+					RoleRef: rbacApi.RoleRef{
+						APIGroup: rbacApi.GroupName,
+						Kind:     rbac.ClusterRoleKind,
+						Name:     "view",
+					},
+				},
+			},
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, stage *cdPipeApi.Stage, k8sClient client.Client) {
+				rb := &rbacApi.RoleBinding{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Name:      tenantAdminRbName,
+					Namespace: stage.Spec.Namespace,
+				}, rb))
+
+				require.Len(t, rb.Subjects, 2)
+				assert.Equal(t, "test-ns-oidc-admins", rb.Subjects[0].Name)
+				assert.Equal(t, "test-ns-oidc-developers", rb.Subjects[1].Name)
+				assert.Equal(t, "admin", rb.RoleRef.Name)
+				assert.Equal(t, rbac.ManagedByValue, rb.Labels[rbac.ManagedByLabel])
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -99,6 +145,241 @@ func TestConfigureTenantAdminRbac_ServeRequest(t *testing.T) {
 	}
 }
 
+func TestConfigureTenantAdminRbac_ServeRequest_AccessControl(t *testing.T) {
+	t.Parallel()
+
+	const (
+		namespace       = "test-ns"
+		targetNamespace = "stage-1-ns"
+	)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, cdPipeApi.AddToScheme(scheme))
+	require.NoError(t, rbacApi.AddToScheme(scheme))
+
+	newStage := func(accessControl ...cdPipeApi.AccessControlBinding) *cdPipeApi.Stage {
+		return &cdPipeApi.Stage{
+			ObjectMeta: metaV1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "test-stage",
+			},
+			Spec: cdPipeApi.StageSpec{
+				Namespace:     targetNamespace,
+				AccessControl: accessControl,
+			},
+		}
+	}
+
+	developers := cdPipeApi.AccessControlBinding{
+		Name: "developers",
+		Subjects: []cdPipeApi.AccessControlSubject{
+			{Kind: rbacApi.GroupKind, Name: "team-developers"},
+			{Kind: rbacApi.ServiceAccountKind, Name: "deployer"},
+		},
+		RoleRef: cdPipeApi.AccessControlRoleRef{Name: "edit"},
+	}
+
+	accessControlRb := func(name string) *rbacApi.RoleBinding {
+		return &rbacApi.RoleBinding{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      accessControlRbPrefix + name,
+				Namespace: targetNamespace,
+				Labels: map[string]string{
+					accessControlLabel:  name,
+					rbac.ManagedByLabel: rbac.ManagedByValue,
+				},
+			},
+			RoleRef: rbacApi.RoleRef{
+				APIGroup: rbacApi.GroupName,
+				Kind:     rbac.ClusterRoleKind,
+				Name:     "view",
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		stage     *cdPipeApi.Stage
+		objects   []runtime.Object
+		wantErr   require.ErrorAssertionFunc
+		wantCheck func(t *testing.T, k8sClient client.Client)
+	}{
+		{
+			name:  "access control rolebindings replace tenant admin rolebinding",
+			stage: newStage(developers),
+			objects: []runtime.Object{
+				&rbacApi.RoleBinding{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      tenantAdminRbName,
+						Namespace: targetNamespace,
+					},
+				},
+			},
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				rb := &rbacApi.RoleBinding{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Name:      "access-control-developers",
+					Namespace: targetNamespace,
+				}, rb))
+
+				assert.Equal(t, "developers", rb.Labels[accessControlLabel])
+				assert.Equal(t, []rbacApi.Subject{
+					{
+						APIGroup: rbacApi.GroupName,
+						Kind:     rbacApi.GroupKind,
+						Name:     "team-developers",
+					},
+					{
+						Kind:      rbacApi.ServiceAccountKind,
+						Name:      "deployer",
+						Namespace: targetNamespace,
+					},
+				}, rb.Subjects)
+				assert.Equal(t, rbacApi.RoleRef{
+					APIGroup: rbacApi.GroupName,
+					Kind:     rbac.ClusterRoleKind,
+					Name:     "edit",
+				}, rb.RoleRef)
+
+				err := k8sClient.Get(context.Background(), client.ObjectKey{
+					Name:      tenantAdminRbName,
+					Namespace: targetNamespace,
+				}, &rbacApi.RoleBinding{})
+				require.Error(t, err)
+			},
+		},
+		{
+			name:  "removed access control rolebindings are deleted",
+			stage: newStage(developers),
+			objects: []runtime.Object{
+				accessControlRb("developers"),
+				accessControlRb("viewers"),
+			},
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				rbs := &rbacApi.RoleBindingList{}
+				require.NoError(t, k8sClient.List(context.Background(), rbs, client.InNamespace(targetNamespace)))
+				require.Len(t, rbs.Items, 1)
+				assert.Equal(t, "access-control-developers", rbs.Items[0].Name)
+				assert.Equal(t, "edit", rbs.Items[0].RoleRef.Name)
+			},
+		},
+		{
+			name: "ServiceAccount from another namespace is bound in the Stage namespace",
+			stage: newStage(cdPipeApi.AccessControlBinding{
+				Name: "deployers",
+				Subjects: []cdPipeApi.AccessControlSubject{
+					{Kind: rbacApi.ServiceAccountKind, Name: "deployer", Namespace: "kube-system"},
+				},
+				RoleRef: cdPipeApi.AccessControlRoleRef{Name: "edit"},
+			}),
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				rb := &rbacApi.RoleBinding{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Name:      "access-control-deployers",
+					Namespace: targetNamespace,
+				}, rb))
+				assert.Equal(t, []rbacApi.Subject{{
+					Kind:      rbacApi.ServiceAccountKind,
+					Name:      "deployer",
+					Namespace: targetNamespace,
+				}}, rb.Subjects)
+			},
+		},
+		{
+			name: "access control role is not allowed",
+			stage: newStage(cdPipeApi.AccessControlBinding{
+				Name:     "admins",
+				Subjects: []cdPipeApi.AccessControlSubject{{Kind: rbacApi.GroupKind, Name: "admins"}},
+				RoleRef:  cdPipeApi.AccessControlRoleRef{Name: "cluster-admin"},
+			}),
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "role cluster-admin of access control binding admins is not allowed")
+			},
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				rbs := &rbacApi.RoleBindingList{}
+				require.NoError(t, k8sClient.List(context.Background(), rbs, client.InNamespace(targetNamespace)))
+				assert.Empty(t, rbs.Items)
+			},
+		},
+		{
+			name:  "access control is removed from stage",
+			stage: newStage(),
+			objects: []runtime.Object{
+				accessControlRb("viewers"),
+			},
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				rbs := &rbacApi.RoleBindingList{}
+				require.NoError(t, k8sClient.List(context.Background(), rbs, client.InNamespace(targetNamespace)))
+				require.Len(t, rbs.Items, 1)
+				assert.Equal(t, tenantAdminRbName, rbs.Items[0].Name)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tt.objects...).Build()
+
+			h := ConfigureTenantAdminRbac{
+				rbac: rbac.NewRbacManager(k8sClient, logr.Discard()),
+			}
+
+			err := h.ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), tt.stage)
+			tt.wantErr(t, err)
+			tt.wantCheck(t, k8sClient)
+		})
+	}
+}
+
+func TestConfigureTenantAdminRbac_ServeRequest_HNC(t *testing.T) {
+	t.Setenv(platform.TypeEnv, platform.Kubernetes)
+	t.Setenv(platform.TenancyEngineEnv, platform.TenancyEngineHNC)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, rbacApi.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	h := ConfigureTenantAdminRbac{
+		rbac: rbac.NewRbacManager(k8sClient, logr.Discard()),
+	}
+
+	stage := &cdPipeApi.Stage{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "test-ns",
+			Name:      "test-stage",
+		},
+		Spec: cdPipeApi.StageSpec{
+			Namespace:   "stage-1-ns",
+			ClusterName: cdPipeApi.InCluster,
+		},
+	}
+
+	require.NoError(t, h.ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), stage))
+
+	roleBindings := &rbacApi.RoleBindingList{}
+	require.NoError(t, k8sClient.List(context.Background(), roleBindings))
+	assert.Empty(t, roleBindings.Items, "RBAC should be propagated from the parent namespace")
+
+	stage.Spec.AccessControl = []cdPipeApi.AccessControlBinding{
+		{
+			Name:     "viewers",
+			RoleRef:  cdPipeApi.AccessControlRoleRef{Name: "view"},
+			Subjects: []cdPipeApi.AccessControlSubject{{Kind: rbacApi.GroupKind, Name: "viewers"}},
+		},
+	}
+
+	err := h.ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), stage)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "access control is not supported in HNC subnamespace")
+}
+
 func TestGetOIDCDeveloperGroupName(t *testing.T) {
 	tests := []struct {
 		name           string
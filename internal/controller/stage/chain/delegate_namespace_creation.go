@@ -4,6 +4,7 @@ import (
 	"context"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
@@ -11,7 +12,8 @@ import (
 
 // DelegateNamespaceCreation is a stage chain element that decides whether to create a namespace or project.
 type DelegateNamespaceCreation struct {
-	client multiClusterClient
+	client         multiClusterClient
+	internalClient client.Client
 }
 
 // ServeRequest is responsible for delegating the creation of a namespace or project
@@ -22,7 +24,7 @@ func (c DelegateNamespaceCreation) ServeRequest(ctx context.Context, stage *cdPi
 	if !platform.ManageNamespace() {
 		logger.Info("Namespace is not managed by the operator")
 
-		return CheckNamespaceExist(c).ServeRequest(ctx, stage)
+		return CheckNamespaceExist{client: c.client}.ServeRequest(ctx, stage)
 	}
 
 	if platform.IsKubernetes() {
@@ -31,19 +33,36 @@ func (c DelegateNamespaceCreation) ServeRequest(ctx context.Context, stage *cdPi
 		if !stage.InCluster() {
 			logger.Info("Stage is not in cluster. Skip multi-tenancy engines")
 
-			return PutNamespace(c).ServeRequest(ctx, stage)
+			return PutNamespace{client: c.client}.ServeRequest(ctx, stage)
 		}
 
 		if platform.CapsuleEnabled() {
 			logger.Info("Capsule is enabled")
-		} else {
-			logger.Info("None of multi-tenancy engines is enabled")
+
+			return PutCapsuleNamespace{client: c.client}.ServeRequest(ctx, stage)
+		}
+
+		if platform.HNCEnabled() {
+			logger.Info("HNC is enabled")
+
+			return PutSubnamespaceAnchor{client: c.client}.ServeRequest(ctx, stage)
 		}
 
-		return PutNamespace(c).ServeRequest(ctx, stage)
+		logger.Info("None of multi-tenancy engines is enabled")
+
+		return PutNamespace{client: c.client}.ServeRequest(ctx, stage)
 	}
 
 	logger.Info("Platform is openshift")
 
-	return PutOpenshiftProject(c).ServeRequest(ctx, stage)
+	return PutOpenshiftProject{
+		client:         c.client,
+		internalClient: c.internalClient,
+	}.ServeRequest(ctx, stage)
+}
+
+// isHNCSubnamespace returns true if the Stage namespace is created as an HNC subnamespace.
+// RBAC and secrets of such namespace are propagated from the parent namespace by HNC.
+func isHNCSubnamespace(stage *cdPipeApi.Stage) bool {
+	return platform.ManageNamespace() && platform.IsKubernetes() && platform.HNCEnabled() && stage.InCluster()
 }
@@ -15,6 +15,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/capsule"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/hnc"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
 )
 
@@ -23,6 +25,8 @@ func TestDelegateNamespaceCreation_ServeRequest(t *testing.T) {
 
 	require.NoError(t, projectApi.AddToScheme(scheme))
 	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, hnc.AddToScheme(scheme))
+	require.NoError(t, cdPipeApi.AddToScheme(scheme))
 
 	tests := []struct {
 		name       string
@@ -45,6 +49,7 @@ func TestDelegateNamespaceCreation_ServeRequest(t *testing.T) {
 				Spec: cdPipeApi.StageSpec{
 					Namespace:   "default-stage-1",
 					ClusterName: cdPipeApi.InCluster,
+					CdPipeline:  "pipeline",
 				},
 			},
 			wantErr: require.NoError,
@@ -181,6 +186,69 @@ func TestDelegateNamespaceCreation_ServeRequest(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "namespace is created in capsule tenant",
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.TypeEnv, platform.Kubernetes)
+				t.Setenv(platform.ManageNamespaceEnv, "true")
+				t.Setenv(platform.TenancyEngineEnv, platform.TenancyEngineCapsule)
+			},
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace:   "default-stage-1",
+					ClusterName: cdPipeApi.InCluster,
+				},
+			},
+			objects: []client.Object{
+				capsule.NewTenant("edp-workload-default"),
+			},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client, s *cdPipeApi.Stage) {
+				ns := &corev1.Namespace{}
+				require.NoError(t,
+					c.Get(
+						context.Background(),
+						client.ObjectKey{Name: s.Spec.Namespace}, ns,
+					),
+				)
+				require.Equal(t, "edp-workload-default", ns.Labels[capsule.TenantLabel])
+			},
+		},
+		{
+			name: "subnamespace anchor is created in hnc mode",
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.TypeEnv, platform.Kubernetes)
+				t.Setenv(platform.ManageNamespaceEnv, "true")
+				t.Setenv(platform.TenancyEngineEnv, platform.TenancyEngineHNC)
+				t.Setenv(platform.HNCParentNamespace, "default-stages")
+			},
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "stage-1",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Namespace:   "default-stage-1",
+					ClusterName: cdPipeApi.InCluster,
+				},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "subnamespace default-stage-1 of default-stages is not ready")
+			},
+			wantAssert: func(t *testing.T, c client.Client, s *cdPipeApi.Stage) {
+				require.NoError(t,
+					c.Get(
+						context.Background(),
+						client.ObjectKey{Name: s.Spec.Namespace, Namespace: "default-stages"}, &hnc.SubnamespaceAnchor{},
+					),
+				)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -189,6 +257,12 @@ func TestDelegateNamespaceCreation_ServeRequest(t *testing.T) {
 
 			c := DelegateNamespaceCreation{
 				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+				internalClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&cdPipeApi.CDPipeline{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "pipeline",
+						Namespace: "default",
+					},
+				}).Build(),
 			}
 
 			err := c.ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), tt.stage)
@@ -15,7 +15,7 @@ type DelegateNamespaceDeletion struct {
 }
 
 // ServeRequest is responsible for delegating the deletion of a namespace or project
-// based on the platform and the configuration of the stage.
+// based on the platform, the configuration and the deletion policy of the stage.
 func (c DelegateNamespaceDeletion) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
 	logger := ctrl.LoggerFrom(ctx)
 
@@ -25,6 +25,13 @@ func (c DelegateNamespaceDeletion) ServeRequest(ctx context.Context, stage *cdPi
 		return Skip{}.ServeRequest(ctx, stage)
 	}
 
+	// Retained namespaces don't reach this handler, see CreateDeleteChain.
+	if stage.GetDeletionPolicy() == cdPipeApi.DeletionPolicyOrphan {
+		logger.Info("Namespace is orphaned according to the deletion policy")
+
+		return OrphanNamespace(c).ServeRequest(ctx, stage)
+	}
+
 	if platform.IsKubernetes() {
 		logger.Info("Platform is kubernetes")
 
@@ -36,10 +43,18 @@ func (c DelegateNamespaceDeletion) ServeRequest(ctx context.Context, stage *cdPi
 
 		if platform.CapsuleEnabled() {
 			logger.Info("Capsule is enabled")
-		} else {
-			logger.Info("None of multi-tenancy engines is enabled")
+
+			return DeleteNamespace(c).ServeRequest(ctx, stage)
 		}
 
+		if platform.HNCEnabled() {
+			logger.Info("HNC is enabled")
+
+			return DeleteSubnamespaceAnchor(c).ServeRequest(ctx, stage)
+		}
+
+		logger.Info("None of multi-tenancy engines is enabled")
+
 		return DeleteNamespace(c).ServeRequest(ctx, stage)
 	}
 
@@ -16,6 +16,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/capsule"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/hnc"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
 )
 
@@ -24,6 +27,7 @@ func TestDelegateNamespaceDeletion_ServeRequest(t *testing.T) {
 
 	require.NoError(t, projectApi.AddToScheme(scheme))
 	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, hnc.AddToScheme(scheme))
 
 	makeAssertNotFoundFunc := func(obj client.Object) func(t *testing.T, c client.Client, s *cdPipeApi.Stage) {
 		return func(t *testing.T, c client.Client, s *cdPipeApi.Stage) {
@@ -150,6 +154,52 @@ func TestDelegateNamespaceDeletion_ServeRequest(t *testing.T) {
 				require.Error(t, err)
 			},
 		},
+		{
+			name: "namespace is orphaned by deletion policy",
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.TypeEnv, platform.Openshift)
+			},
+			stage: func() *cdPipeApi.Stage {
+				s := makeStage()
+				s.Spec.DeletionPolicy = cdPipeApi.DeletionPolicyOrphan
+
+				return s
+			}(),
+			objects: []client.Object{
+				&corev1.Namespace{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name: "default-stage-1",
+						Labels: map[string]string{
+							util.TenantLabelName: "default",
+							capsule.TenantLabel:  "default",
+							"custom":             "value",
+						},
+					},
+				},
+			},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client, s *cdPipeApi.Stage) {
+				ns := &corev1.Namespace{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: s.Spec.Namespace}, ns))
+				require.NotContains(t, ns.Labels, util.TenantLabelName)
+				require.NotContains(t, ns.Labels, capsule.TenantLabel)
+				require.Equal(t, "value", ns.Labels["custom"])
+			},
+		},
+		{
+			name: "orphaned namespace doesn't exist",
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.TypeEnv, platform.Kubernetes)
+			},
+			stage: func() *cdPipeApi.Stage {
+				s := makeStage()
+				s.Spec.DeletionPolicy = cdPipeApi.DeletionPolicyOrphan
+
+				return s
+			}(),
+			wantErr:    require.NoError,
+			wantAssert: makeAssertNotFoundFunc(&corev1.Namespace{}),
+		},
 		{
 			name: "namespace is not managed by operator",
 			prepare: func(t *testing.T) {
@@ -182,6 +232,31 @@ func TestDelegateNamespaceDeletion_ServeRequest(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
+		{
+			name: "subnamespace anchor is deleted",
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.TypeEnv, platform.Kubernetes)
+				t.Setenv(platform.TenancyEngineEnv, platform.TenancyEngineHNC)
+				t.Setenv(platform.HNCParentNamespace, "default-stages")
+			},
+			stage: makeStage(),
+			objects: []client.Object{
+				&hnc.SubnamespaceAnchor{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "default-stage-1",
+						Namespace: "default-stages",
+					},
+				},
+			},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client, s *cdPipeApi.Stage) {
+				err := c.Get(
+					context.Background(),
+					client.ObjectKey{Name: s.Spec.Namespace, Namespace: "default-stages"}, &hnc.SubnamespaceAnchor{},
+				)
+				require.True(t, apiErrors.IsNotFound(err))
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,209 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacApi "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/externalsecrets"
+)
+
+// DeleteSecretManager is a handler that deletes the resources created by ConfigureSecretManager.
+type DeleteSecretManager struct {
+	multiClusterClient multiClusterClient
+	internalClient     client.Client
+}
+
+// ServeRequest deletes the secret manager resources of the Stage namespace.
+// The resources of all modes are deleted, because the mode can be changed after they were created.
+// In the own mode, only the Secrets synced by the operator are deleted.
+func (h DeleteSecretManager) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
+	if isHNCSubnamespace(stage) {
+		return nil
+	}
+
+	if err := deleteEsoRoleBinding(ctx, h.multiClusterClient, stage); err != nil {
+		return err
+	}
+
+	secrets := util.GetImagePullSecrets(stage)
+
+	if err := h.deleteEso(ctx, stage, secrets); err != nil {
+		return err
+	}
+
+	for _, name := range secrets {
+		if err := h.deleteSecretCopy(ctx, stage.Spec.Namespace, name); err != nil {
+			return err
+		}
+	}
+
+	return h.detachImagePullSecrets(ctx, stage.Spec.Namespace, secrets)
+}
+
+// deleteEso deletes the ExternalSecrets, SecretStore and ServiceAccount of the external secret integration.
+func (h DeleteSecretManager) deleteEso(ctx context.Context, stage *cdPipeApi.Stage, secrets []string) error {
+	l := ctrl.LoggerFrom(ctx).WithValues("namespace", stage.Spec.Namespace)
+
+	externalSecretNames := secrets
+
+	storeTemplate, err := getSecretStoreTemplate(ctx, h.internalClient, stage)
+	if err != nil {
+		// The template can be deleted or broken, the ExternalSecrets of the image pull secrets are deleted anyway.
+		l.Error(err, "Failed to get SecretStore template")
+	}
+
+	if storeTemplate != nil && len(storeTemplate.ExternalSecrets) > 0 {
+		externalSecretNames = make([]string, 0, len(storeTemplate.ExternalSecrets))
+
+		for _, es := range storeTemplate.ExternalSecrets {
+			externalSecretNames = append(externalSecretNames, es.Name)
+		}
+	}
+
+	objects := make([]client.Object, 0, len(externalSecretNames)+2)
+
+	for _, name := range externalSecretNames {
+		objects = append(objects, externalsecrets.NewExternalSecret(name, stage.Spec.Namespace))
+	}
+
+	objects = append(objects,
+		externalsecrets.NewSecretStore(secretStoreName, stage.Spec.Namespace),
+		&corev1.ServiceAccount{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      secretIntegrationServiceAccountName,
+				Namespace: stage.Spec.Namespace,
+			},
+		},
+	)
+
+	for _, obj := range objects {
+		if err = h.multiClusterClient.Delete(ctx, obj); err != nil {
+			// ESO CRDs can be uninstalled from the cluster.
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+
+			return fmt.Errorf("failed to delete %s: %w", obj.GetName(), err)
+		}
+	}
+
+	l.Info("External secret integration has been deleted")
+
+	return nil
+}
+
+// DeleteEsoRoleBinding is a handler that deletes the RoleBinding of the external secret integration
+// in the tenant namespace. It is used if the Stage namespaces are retained,
+// so the retained namespace doesn't keep access to the tenant secrets.
+type DeleteEsoRoleBinding struct {
+	multiClusterClient multiClusterClient
+}
+
+// ServeRequest deletes the external secret integration RoleBinding of the Stage.
+func (h DeleteEsoRoleBinding) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
+	return deleteEsoRoleBinding(ctx, h.multiClusterClient, stage)
+}
+
+// deleteEsoRoleBinding deletes the RoleBinding that grants the Stage namespace access to the tenant secrets,
+// so it doesn't grant access if the namespace is recreated or retained.
+func deleteEsoRoleBinding(ctx context.Context, c multiClusterClient, stage *cdPipeApi.Stage) error {
+	rb := &rbacApi.RoleBinding{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      fmt.Sprintf("eso-%s", stage.Spec.Namespace),
+			Namespace: stage.Namespace,
+		},
+	}
+
+	if err := c.Delete(ctx, rb); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to delete %s: %w", rb.Name, err)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("External secret integration RoleBinding has been deleted", "name", rb.Name)
+
+	return nil
+}
+
+// detachImagePullSecrets removes the image pull secrets from the default ServiceAccount of the namespace.
+func (h DeleteSecretManager) detachImagePullSecrets(ctx context.Context, namespace string, secrets []string) error {
+	serviceAccount := &corev1.ServiceAccount{}
+
+	if err := h.multiClusterClient.Get(ctx, client.ObjectKey{
+		Namespace: namespace,
+		Name:      defaultServiceAccountName,
+	}, serviceAccount); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get %s service account: %w", defaultServiceAccountName, err)
+	}
+
+	patch := client.MergeFrom(serviceAccount.DeepCopy())
+
+	refs := slices.DeleteFunc(slices.Clone(serviceAccount.ImagePullSecrets), func(ref corev1.LocalObjectReference) bool {
+		return slices.Contains(secrets, ref.Name)
+	})
+
+	if len(refs) == len(serviceAccount.ImagePullSecrets) {
+		return nil
+	}
+
+	serviceAccount.ImagePullSecrets = refs
+
+	if err := h.multiClusterClient.Patch(ctx, serviceAccount, patch); err != nil {
+		return fmt.Errorf("failed to detach image pull secrets from %s service account: %w", defaultServiceAccountName, err)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Image pull secrets have been detached from the default service account")
+
+	return nil
+}
+
+func (h DeleteSecretManager) deleteSecretCopy(ctx context.Context, namespace, name string) error {
+	l := ctrl.LoggerFrom(ctx).WithValues("namespace", namespace, "secret", name)
+
+	secret := &corev1.Secret{}
+
+	err := h.multiClusterClient.Get(ctx, client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			l.Info("Secret has already been deleted")
+
+			return nil
+		}
+
+		return fmt.Errorf("failed to get %s secret copy: %w", name, err)
+	}
+
+	if _, ok := secret.Annotations[secretHashAnnotation]; !ok {
+		l.Info("Secret is not managed by the operator. Skip deletion")
+
+		return nil
+	}
+
+	if err = h.multiClusterClient.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete %s secret copy: %w", name, err)
+	}
+
+	l.Info("Secret has been deleted")
+
+	return nil
+}
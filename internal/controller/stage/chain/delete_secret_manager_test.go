@@ -0,0 +1,271 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacApi "k8s.io/api/rbac/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/externalsecrets"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
+)
+
+func TestDeleteSecretManager_ServeRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, cdPipeApi.AddToScheme(scheme))
+	require.NoError(t, rbacApi.AddToScheme(scheme))
+
+	stage := &cdPipeApi.Stage{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "stage-1",
+			Namespace: "default",
+		},
+		Spec: cdPipeApi.StageSpec{
+			Namespace:  "test-namespace",
+			CdPipeline: "mypipeline",
+		},
+	}
+
+	newSecret := func(annotations map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:        platform.DefaultImagePullSecret,
+				Namespace:   "test-namespace",
+				Annotations: annotations,
+			},
+		}
+	}
+
+	secretExists := func(t *testing.T, c client.Client) error {
+		return c.Get(context.Background(), client.ObjectKey{
+			Namespace: "test-namespace",
+			Name:      platform.DefaultImagePullSecret,
+		}, &corev1.Secret{})
+	}
+
+	tests := []struct {
+		name       string
+		objects    []client.Object
+		setup      func(t *testing.T)
+		wantErr    require.ErrorAssertionFunc
+		wantAssert func(t *testing.T, c client.Client)
+	}{
+		{
+			name:    "synced secret is deleted",
+			objects: []client.Object{newSecret(map[string]string{secretHashAnnotation: "hash"})},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerOwn)
+			},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {
+				require.True(t, k8sErrors.IsNotFound(secretExists(t, c)))
+			},
+		},
+		{
+			name:    "secret is not managed by operator",
+			objects: []client.Object{newSecret(nil)},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerOwn)
+			},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {
+				require.NoError(t, secretExists(t, c))
+			},
+		},
+		{
+			name: "secret doesn't exist",
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerOwn)
+			},
+			wantErr:    require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {},
+		},
+		{
+			name: "own secret manager is set by CDPipeline",
+			objects: []client.Object{
+				newSecret(map[string]string{secretHashAnnotation: "hash"}),
+				&cdPipeApi.CDPipeline{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "mypipeline",
+						Namespace: "default",
+						Annotations: map[string]string{
+							cdPipeApi.SecretManagerAnnotation: secretManagerOwn,
+						},
+					},
+				},
+			},
+			setup:   func(t *testing.T) {},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {
+				require.True(t, k8sErrors.IsNotFound(secretExists(t, c)))
+			},
+		},
+		{
+			name: "external secret integration is deleted",
+			objects: []client.Object{
+				externalsecrets.NewExternalSecret(platform.DefaultImagePullSecret, "test-namespace"),
+				externalsecrets.NewSecretStore(secretStoreName, "test-namespace"),
+				&corev1.ServiceAccount{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      secretIntegrationServiceAccountName,
+						Namespace: "test-namespace",
+					},
+				},
+				&corev1.ServiceAccount{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      defaultServiceAccountName,
+						Namespace: "test-namespace",
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{
+						{Name: "default-dockercfg"},
+						{Name: platform.DefaultImagePullSecret},
+					},
+				},
+				&rbacApi.RoleBinding{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "eso-test-namespace",
+						Namespace: "default",
+					},
+				},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerESO)
+			},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {
+				ctx := context.Background()
+
+				err := c.Get(ctx, client.ObjectKey{Namespace: "test-namespace", Name: platform.DefaultImagePullSecret},
+					externalsecrets.NewExternalSecret(platform.DefaultImagePullSecret, "test-namespace"))
+				require.True(t, k8sErrors.IsNotFound(err))
+
+				err = c.Get(ctx, client.ObjectKey{Namespace: "test-namespace", Name: secretStoreName},
+					externalsecrets.NewSecretStore(secretStoreName, "test-namespace"))
+				require.True(t, k8sErrors.IsNotFound(err))
+
+				err = c.Get(ctx, client.ObjectKey{Namespace: "test-namespace", Name: secretIntegrationServiceAccountName},
+					&corev1.ServiceAccount{})
+				require.True(t, k8sErrors.IsNotFound(err))
+
+				err = c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "eso-test-namespace"}, &rbacApi.RoleBinding{})
+				require.True(t, k8sErrors.IsNotFound(err))
+
+				serviceAccount := &corev1.ServiceAccount{}
+				require.NoError(t, c.Get(ctx, client.ObjectKey{
+					Namespace: "test-namespace",
+					Name:      defaultServiceAccountName,
+				}, serviceAccount))
+				require.Equal(t, []corev1.LocalObjectReference{{Name: "default-dockercfg"}}, serviceAccount.ImagePullSecrets)
+			},
+		},
+		{
+			name: "external secret integration doesn't exist",
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, secretManagerESO)
+			},
+			wantErr:    require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {},
+		},
+		{
+			name: "resources of the previous secret manager are deleted",
+			objects: []client.Object{
+				newSecret(map[string]string{secretHashAnnotation: "hash"}),
+				&rbacApi.RoleBinding{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "eso-test-namespace",
+						Namespace: "default",
+					},
+				},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv(secretManagerEnv, "none")
+			},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {
+				require.True(t, k8sErrors.IsNotFound(secretExists(t, c)))
+
+				err := c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "eso-test-namespace"},
+					&rbacApi.RoleBinding{})
+				require.True(t, k8sErrors.IsNotFound(err))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+
+			err := DeleteSecretManager{multiClusterClient: c, internalClient: c}.ServeRequest(
+				ctrl.LoggerInto(context.Background(), logr.Discard()),
+				stage.DeepCopy(),
+			)
+
+			tt.wantErr(t, err)
+			tt.wantAssert(t, c)
+		})
+	}
+}
+
+func TestDeleteEsoRoleBinding_ServeRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, rbacApi.AddToScheme(scheme))
+
+	stage := &cdPipeApi.Stage{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "stage-1",
+			Namespace: "default",
+		},
+		Spec: cdPipeApi.StageSpec{
+			Namespace:      "test-namespace",
+			DeletionPolicy: cdPipeApi.DeletionPolicyRetain,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+	}{
+		{
+			name: "role binding is deleted",
+			objects: []client.Object{
+				&rbacApi.RoleBinding{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "eso-test-namespace",
+						Namespace: "default",
+					},
+				},
+			},
+		},
+		{
+			name: "role binding doesn't exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+
+			require.NoError(t, DeleteEsoRoleBinding{multiClusterClient: c}.ServeRequest(
+				ctrl.LoggerInto(context.Background(), logr.Discard()),
+				stage.DeepCopy(),
+			))
+
+			err := c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "eso-test-namespace"},
+				&rbacApi.RoleBinding{})
+			require.True(t, k8sErrors.IsNotFound(err))
+		})
+	}
+}
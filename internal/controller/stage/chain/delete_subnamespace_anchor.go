@@ -0,0 +1,52 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/hnc"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
+)
+
+// DeleteSubnamespaceAnchor is a handler that deletes a Stage HNC subnamespace.
+type DeleteSubnamespaceAnchor struct {
+	multiClusterClient multiClusterClient
+}
+
+// ServeRequest deletes the SubnamespaceAnchor, HNC deletes the subnamespace after that.
+func (h DeleteSubnamespaceAnchor) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
+	parent, err := platform.GetHNCParentNamespace(stage.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get HNC parent namespace: %w", err)
+	}
+
+	l := ctrl.LoggerFrom(ctx).WithValues("namespace", stage.Spec.Namespace, "parent", parent)
+
+	l.Info("Deleting subnamespace anchor")
+
+	anchor := &hnc.SubnamespaceAnchor{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      stage.Spec.Namespace,
+			Namespace: parent,
+		},
+	}
+
+	if err = h.multiClusterClient.Delete(ctx, anchor); err != nil {
+		if apierrors.IsNotFound(err) {
+			l.Info("Subnamespace anchor has already been deleted")
+
+			return nil
+		}
+
+		return fmt.Errorf("failed to delete subnamespace anchor: %w", err)
+	}
+
+	l.Info("Subnamespace anchor has been deleted")
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/hnc"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
+)
+
+func TestDeleteSubnamespaceAnchor_ServeRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, hnc.AddToScheme(scheme))
+
+	stage := &cdPipeApi.Stage{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "stage-1",
+			Namespace: "krci",
+		},
+		Spec: cdPipeApi.StageSpec{
+			Namespace:   "krci-stage-1",
+			ClusterName: cdPipeApi.InCluster,
+		},
+	}
+
+	newAnchor := func(namespace string) *hnc.SubnamespaceAnchor {
+		return &hnc.SubnamespaceAnchor{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      "krci-stage-1",
+				Namespace: namespace,
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		parent     string
+		objects    []client.Object
+		wantErr    require.ErrorAssertionFunc
+		wantAssert func(t *testing.T, c client.Client)
+	}{
+		{
+			name:    "anchor is deleted from the parent namespace",
+			parent:  "krci-stages",
+			objects: []client.Object{newAnchor("krci-stages")},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {
+				err := c.Get(context.Background(), client.ObjectKey{
+					Name:      "krci-stage-1",
+					Namespace: "krci-stages",
+				}, &hnc.SubnamespaceAnchor{})
+				require.True(t, k8sErrors.IsNotFound(err))
+			},
+		},
+		{
+			name:       "anchor has already been deleted",
+			parent:     "krci-stages",
+			wantErr:    require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {},
+		},
+		{
+			name:    "parent namespace is not set",
+			objects: []client.Object{newAnchor("krci-stages")},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "HNC_PARENT_NAMESPACE is not set")
+			},
+			wantAssert: func(t *testing.T, c client.Client) {
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{
+					Name:      "krci-stage-1",
+					Namespace: "krci-stages",
+				}, &hnc.SubnamespaceAnchor{}))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(platform.HNCParentNamespace, tt.parent)
+
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+
+			err := DeleteSubnamespaceAnchor{multiClusterClient: c}.ServeRequest(
+				ctrl.LoggerInto(context.Background(), logr.Discard()),
+				stage.DeepCopy(),
+			)
+
+			tt.wantErr(t, err)
+			tt.wantAssert(t, c)
+		})
+	}
+}
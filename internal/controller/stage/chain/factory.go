@@ -19,25 +19,33 @@ import (
 type multiClusterClient client.Client
 
 func CreateChain(ctx context.Context, c client.Client, stage *cdPipeApi.Stage) (handler.CdStageHandler, error) {
-	multiClusterCl, err := multiclusterclient.NewClientProvider(c).GetClusterClient(
-		ctx,
-		stage.Namespace,
-		stage.Spec.ClusterName,
-		client.Options{},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get cluster internalClient: %w", err)
-	}
+	clientProvider := multiclusterclient.NewClientProvider(c)
+	targets := make([]clusterTargetChain, 0, len(stage.Spec.Clusters)+1)
 
-	rbacManager := rbac.NewRbacManager(multiClusterCl, ctrl.Log.WithName("rbac-manager"))
+	for _, target := range stage.GetClusterTargets() {
+		multiClusterCl, err := clientProvider.GetClusterClient(
+			ctx,
+			stage.Namespace,
+			target.Name,
+			client.Options{},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster %s internalClient: %w", target.Name, err)
+		}
+
+		targets = append(targets, clusterTargetChain{
+			target: target,
+			chain:  createClusterTargetChain(c, multiClusterCl),
+		})
+	}
 
 	ch := &chain{}
 	ch.Use(
 		PutCodebaseImageStream{
 			client: c,
 		},
-		DelegateNamespaceCreation{
-			client: multiClusterCl,
+		ServeClusterTargets{
+			targets: targets,
 		},
 		RemoveLabelsFromCodebaseDockerStreamsAfterCdPipelineUpdate{
 			client: c,
@@ -48,6 +56,29 @@ func CreateChain(ctx context.Context, c client.Client, stage *cdPipeApi.Stage) (
 		PutEnvironmentLabelToCodebaseImageStreams{
 			client: c,
 		},
+		AddApplicationSetGenerators{
+			applicationSetManager: argocd.NewArgoApplicationSetManager(c),
+		},
+		NewPutConfigMap(c),
+	)
+
+	return ch, nil
+}
+
+// createClusterTargetChain creates a chain of handlers that configure a single Stage cluster target.
+func createClusterTargetChain(c client.Client, multiClusterCl client.Client) handler.CdStageHandler {
+	rbacManager := rbac.NewRbacManager(multiClusterCl, ctrl.Log.WithName("rbac-manager"))
+
+	ch := &chain{}
+	ch.Use(
+		DelegateNamespaceCreation{
+			client:         multiClusterCl,
+			internalClient: c,
+		},
+		ApplyNamespaceProfile{
+			multiClusterClient: multiClusterCl,
+			internalClient:     c,
+		},
 		ConfigureRegistryViewerRbac{
 			rbac: rbacManager,
 		},
@@ -58,13 +89,16 @@ func CreateChain(ctx context.Context, c client.Client, stage *cdPipeApi.Stage) (
 			multiClusterClient: multiClusterCl,
 			internalClient:     c,
 		},
-		AddApplicationSetGenerators{
-			applicationSetManager: argocd.NewArgoApplicationSetManager(c),
+		ConfigureServiceAccounts{
+			multiClusterClient: multiClusterCl,
+		},
+		MirrorStageConfigMap{
+			multiClusterClient: multiClusterCl,
+			internalClient:     c,
 		},
-		NewPutConfigMap(c),
 	)
 
-	return ch, nil
+	return ch
 }
 
 func CreateDeleteChain(ctx context.Context, c client.Client, stage *cdPipeApi.Stage) (handler.CdStageHandler, error) {
@@ -80,18 +114,102 @@ func CreateDeleteChain(ctx context.Context, c client.Client, stage *cdPipeApi.St
 		},
 	)
 
-	multiClusterCl, err := multiclusterclient.NewClientProvider(c).GetClusterClient(
-		ctx,
-		stage.Namespace,
-		stage.Spec.ClusterName,
-		client.Options{},
-	)
-	if err != nil {
-		log.Error(err, "Failed to get cluster internalClient. Skipping namespace deletion.")
+	clientProvider := multiclusterclient.NewClientProvider(c)
+
+	// Retained namespaces are kept as is, only the tenant access of the namespaces is revoked.
+	// Unavailable clusters are skipped, so the Stage can be deleted if its cluster is not available anymore.
+	if stage.GetDeletionPolicy() == cdPipeApi.DeletionPolicyRetain {
+		log.Info("Stage namespaces are retained. Skip clusters cleanup")
+
+		ch.Use(
+			ServeClusterTargets{
+				targets: createRetainedClusterTargets(ctx, clientProvider, stage),
+			},
+		)
+
 		return ch, nil
 	}
 
+	targets := make([]clusterTargetChain, 0, len(stage.Spec.Clusters)+1)
+
+	for _, target := range stage.GetClusterTargets() {
+		multiClusterCl, err := clientProvider.GetClusterClient(
+			ctx,
+			stage.Namespace,
+			target.Name,
+			client.Options{},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster %s internalClient: %w", target.Name, err)
+		}
+
+		targets = append(targets, clusterTargetChain{
+			target: target,
+			chain:  createClusterTargetDeleteChain(c, multiClusterCl),
+		})
+	}
+
+	ch.Use(
+		ServeClusterTargets{
+			targets: targets,
+		},
+	)
+
+	return ch, nil
+}
+
+// createRetainedClusterTargets creates cluster targets that revoke the tenant access of the retained namespaces.
+func createRetainedClusterTargets(
+	ctx context.Context,
+	clientProvider *multiclusterclient.ClientProvider,
+	stage *cdPipeApi.Stage,
+) []clusterTargetChain {
+	log := ctrl.LoggerFrom(ctx)
+	targets := make([]clusterTargetChain, 0, len(stage.Spec.Clusters)+1)
+
+	for _, target := range stage.GetClusterTargets() {
+		multiClusterCl, err := clientProvider.GetClusterClient(
+			ctx,
+			stage.Namespace,
+			target.Name,
+			client.Options{},
+		)
+		if err != nil {
+			log.Error(err, "Cluster is not available. Skip retained namespace cleanup", "cluster", target.Name)
+
+			continue
+		}
+
+		targets = append(targets, clusterTargetChain{
+			target: target,
+			chain:  createRetainedClusterTargetDeleteChain(multiClusterCl),
+		})
+	}
+
+	return targets
+}
+
+// createRetainedClusterTargetDeleteChain creates a chain of handlers that clean up
+// a single Stage cluster target with the retained namespace.
+func createRetainedClusterTargetDeleteChain(multiClusterCl client.Client) handler.CdStageHandler {
+	ch := &chain{}
 	ch.Use(
+		DeleteEsoRoleBinding{
+			multiClusterClient: multiClusterCl,
+		},
+	)
+
+	return ch
+}
+
+// createClusterTargetDeleteChain creates a chain of handlers that clean up a single Stage cluster target.
+func createClusterTargetDeleteChain(c client.Client, multiClusterCl client.Client) handler.CdStageHandler {
+	ch := &chain{}
+	ch.Use(
+		DeleteSecretManager{
+			multiClusterClient: multiClusterCl,
+			internalClient:     c,
+		},
 		DelegateNamespaceDeletion{
 			multiClusterClient: multiClusterCl,
 		},
@@ -100,5 +218,5 @@ func CreateDeleteChain(ctx context.Context, c client.Client, stage *cdPipeApi.St
 		},
 	)
 
-	return ch, nil
+	return ch
 }
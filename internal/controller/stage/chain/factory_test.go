@@ -53,8 +53,9 @@ func TestCreateDeleteChain(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name  string
-		stage *cdPipeApi.Stage
+		name    string
+		stage   *cdPipeApi.Stage
+		wantErr require.ErrorAssertionFunc
 	}{
 		{
 			name: "should create delete chain",
@@ -63,23 +64,47 @@ func TestCreateDeleteChain(t *testing.T) {
 					ClusterName: cdPipeApi.InCluster,
 				},
 			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "should fail if cluster is not available",
+			stage: &cdPipeApi.Stage{
+				Spec: cdPipeApi.StageSpec{
+					ClusterName: "unknown-cluster",
+				},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to get cluster unknown-cluster")
+			},
+		},
+		{
+			name: "should not access cluster if namespaces are retained",
+			stage: &cdPipeApi.Stage{
+				Spec: cdPipeApi.StageSpec{
+					ClusterName:    "unknown-cluster",
+					DeletionPolicy: cdPipeApi.DeletionPolicyRetain,
+				},
+			},
+			wantErr: require.NoError,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
 			chain, err := CreateDeleteChain(
 				ctrl.LoggerInto(context.Background(), logr.Discard()),
 				fake.NewClientBuilder().Build(),
-				&cdPipeApi.Stage{
-					Spec: cdPipeApi.StageSpec{
-						ClusterName: cdPipeApi.InCluster,
-					},
-				},
+				tt.stage,
 			)
 
-			assert.NoError(t, err)
-			assert.NotNil(t, chain)
+			tt.wantErr(t, err)
+
+			if err == nil {
+				assert.NotNil(t, chain)
+			}
 		})
 	}
 }
@@ -0,0 +1,100 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+const (
+	// stageMetadataConfigMapName is a name of the ConfigMap with the Stage environment metadata in the Stage namespace.
+	stageMetadataConfigMapName = "stage-metadata"
+	// stageMetadataLabel is a label of the ConfigMap with the Stage environment metadata in the Stage namespace.
+	stageMetadataLabel = "app.edp.epam.com/stage-metadata"
+)
+
+// MirrorStageConfigMap is a stage chain element that copies the Stage environment metadata to the Stage namespace.
+type MirrorStageConfigMap struct {
+	multiClusterClient multiClusterClient
+	internalClient     client.Client
+}
+
+// ServeRequest puts the stage-metadata ConfigMap to the Stage namespace if mirroring is enabled
+// and removes it otherwise.
+func (h MirrorStageConfigMap) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
+	logger := ctrl.LoggerFrom(ctx).WithValues("target-ns", stage.Spec.Namespace)
+
+	if !stage.Spec.MirrorConfigMap {
+		return h.deleteConfigMap(ctx, stage)
+	}
+
+	logger.Info("Mirroring Stage metadata ConfigMap")
+
+	metadata, err := getStageMetadata(ctx, h.internalClient, stage)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      stageMetadataConfigMapName,
+			Namespace: stage.Spec.Namespace,
+		},
+	}
+
+	res, err := controllerutil.CreateOrUpdate(ctx, h.multiClusterClient, cm, func() error {
+		if cm.Labels == nil {
+			cm.Labels = make(map[string]string, 1)
+		}
+
+		cm.Labels[stageMetadataLabel] = "true"
+
+		setStageMetadata(cm, metadata)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mirror Stage metadata ConfigMap: %w", err)
+	}
+
+	logger.Info("Stage metadata ConfigMap has been mirrored", "result", res)
+
+	return nil
+}
+
+func (h MirrorStageConfigMap) deleteConfigMap(ctx context.Context, stage *cdPipeApi.Stage) error {
+	cm := &corev1.ConfigMap{}
+
+	err := h.multiClusterClient.Get(ctx, client.ObjectKey{
+		Namespace: stage.Spec.Namespace,
+		Name:      stageMetadataConfigMapName,
+	}, cm)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get Stage metadata ConfigMap: %w", err)
+	}
+
+	// The ConfigMap with the same name can be created by a user, so only the mirrored one is deleted.
+	if _, ok := cm.Labels[stageMetadataLabel]; !ok {
+		return nil
+	}
+
+	if err = h.multiClusterClient.Delete(ctx, cm); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete Stage metadata ConfigMap: %w", err)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Stage metadata ConfigMap has been deleted", "target-ns", stage.Spec.Namespace)
+
+	return nil
+}
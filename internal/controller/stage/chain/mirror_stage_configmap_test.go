@@ -0,0 +1,160 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	argoApi "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+func TestMirrorStageConfigMap_ServeRequest(t *testing.T) {
+	t.Parallel()
+
+	const namespace = "default-pipe-dev"
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, cdPipeApi.AddToScheme(scheme))
+	require.NoError(t, argoApi.AddToScheme(scheme))
+
+	newStage := func(mirror bool) *cdPipeApi.Stage {
+		return &cdPipeApi.Stage{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      "pipe-dev",
+				Namespace: "default",
+			},
+			Spec: cdPipeApi.StageSpec{
+				Name:            "dev",
+				CdPipeline:      "pipe",
+				ClusterName:     cdPipeApi.InCluster,
+				Namespace:       namespace,
+				MirrorConfigMap: mirror,
+			},
+		}
+	}
+
+	newConfigMap := func(labels, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      stageMetadataConfigMapName,
+				Namespace: namespace,
+				Labels:    labels,
+			},
+			Data: data,
+		}
+	}
+
+	getConfigMap := func(k8sClient client.Client) (*corev1.ConfigMap, error) {
+		cm := &corev1.ConfigMap{}
+		err := k8sClient.Get(context.Background(), client.ObjectKey{
+			Namespace: namespace,
+			Name:      stageMetadataConfigMapName,
+		}, cm)
+
+		return cm, err
+	}
+
+	mirrored := map[string]string{stageMetadataLabel: "true"}
+
+	tests := []struct {
+		name      string
+		stage     *cdPipeApi.Stage
+		objects   []client.Object
+		wantErr   require.ErrorAssertionFunc
+		wantCheck func(t *testing.T, k8sClient client.Client)
+	}{
+		{
+			name:    "configmap is mirrored",
+			stage:   newStage(true),
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				cm, err := getConfigMap(k8sClient)
+				require.NoError(t, err)
+
+				assert.Equal(t, "true", cm.Labels[stageMetadataLabel])
+				assert.Equal(t, map[string]string{
+					"cdPipeline": "pipe",
+					"stage":      "dev",
+					"order":      "0",
+					"cluster":    cdPipeApi.InCluster,
+					"namespace":  namespace,
+				}, cm.Data)
+			},
+		},
+		{
+			name:  "mirrored configmap is updated",
+			stage: newStage(true),
+			objects: []client.Object{
+				newConfigMap(mirrored, map[string]string{"stage": "old", "app.imageTag": "0.0.1", "custom": "value"}),
+			},
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				cm, err := getConfigMap(k8sClient)
+				require.NoError(t, err)
+
+				assert.Equal(t, "dev", cm.Data["stage"])
+				assert.Equal(t, "value", cm.Data["custom"])
+				assert.NotContains(t, cm.Data, "app.imageTag")
+			},
+		},
+		{
+			name:    "mirrored configmap is deleted",
+			stage:   newStage(false),
+			objects: []client.Object{newConfigMap(mirrored, nil)},
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				_, err := getConfigMap(k8sClient)
+				require.True(t, k8sErrors.IsNotFound(err))
+			},
+		},
+		{
+			name:    "user configmap is not deleted",
+			stage:   newStage(false),
+			objects: []client.Object{newConfigMap(nil, nil)},
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				_, err := getConfigMap(k8sClient)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:    "configmap doesn't exist",
+			stage:   newStage(false),
+			wantErr: require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				_, err := getConfigMap(k8sClient)
+				require.True(t, k8sErrors.IsNotFound(err))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+
+			h := MirrorStageConfigMap{
+				multiClusterClient: k8sClient,
+				internalClient:     k8sClient,
+			}
+
+			err := h.ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), tt.stage)
+
+			tt.wantErr(t, err)
+			tt.wantCheck(t, k8sClient)
+		})
+	}
+}
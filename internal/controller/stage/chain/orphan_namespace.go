@@ -0,0 +1,65 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/capsule"
+)
+
+// orphanedLabels are the labels that assign the Stage namespace to the tenant.
+var orphanedLabels = []string{util.TenantLabelName, capsule.TenantLabel}
+
+// OrphanNamespace is a handler that keeps the Stage namespace but removes the tenant labels from it.
+type OrphanNamespace struct {
+	multiClusterClient multiClusterClient
+}
+
+// ServeRequest removes the tenant labels from the Stage namespace,
+// so the namespace isn't counted in the Capsule Tenant anymore.
+func (h OrphanNamespace) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
+	l := ctrl.LoggerFrom(ctx).WithValues("namespace", stage.Spec.Namespace)
+
+	ns := &corev1.Namespace{}
+	if err := h.multiClusterClient.Get(ctx, client.ObjectKey{Name: stage.Spec.Namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			l.Info("Namespace has already been deleted")
+
+			return nil
+		}
+
+		return fmt.Errorf("failed to get namespace: %w", err)
+	}
+
+	if !slices.ContainsFunc(orphanedLabels, func(label string) bool {
+		_, ok := ns.Labels[label]
+
+		return ok
+	}) {
+		l.Info("Namespace has already been orphaned")
+
+		return nil
+	}
+
+	patch := client.MergeFrom(ns.DeepCopy())
+
+	for _, label := range orphanedLabels {
+		delete(ns.Labels, label)
+	}
+
+	if err := h.multiClusterClient.Patch(ctx, ns, patch); err != nil {
+		return fmt.Errorf("failed to remove tenant labels from namespace: %w", err)
+	}
+
+	l.Info("Namespace has been orphaned")
+
+	return nil
+}
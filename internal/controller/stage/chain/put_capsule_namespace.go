@@ -0,0 +1,108 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/capsule"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
+)
+
+// +kubebuilder:rbac:groups=capsule.clastix.io,resources=tenants,verbs=get
+
+// PutCapsuleNamespace is a handler that creates a Stage namespace in the Capsule Tenant.
+type PutCapsuleNamespace struct {
+	client multiClusterClient
+}
+
+// ServeRequest creates a namespace with the Capsule tenant label.
+// The Tenant namespace quota is checked before the creation.
+func (h PutCapsuleNamespace) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
+	tenantName := platform.GetCapsuleTenantName(stage.Namespace)
+	l := ctrl.LoggerFrom(ctx).WithValues("namespace", stage.Spec.Namespace, "capsule-tenant", tenantName)
+
+	ns := &corev1.Namespace{}
+
+	err := h.client.Get(ctx, client.ObjectKey{Name: stage.Spec.Namespace}, ns)
+	if err == nil {
+		if owner, ok := ns.Labels[capsule.TenantLabel]; ok && owner != tenantName {
+			return fmt.Errorf("namespace %s belongs to another Capsule Tenant %s", stage.Spec.Namespace, owner)
+		}
+
+		l.Info("Namespace already exists")
+
+		return nil
+	}
+
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get namespace: %w", err)
+	}
+
+	if err = h.checkTenantQuota(ctx, tenantName); err != nil {
+		return err
+	}
+
+	l.Info("Creating namespace in Capsule Tenant")
+
+	ns = &corev1.Namespace{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name: stage.Spec.Namespace,
+			Labels: map[string]string{
+				util.TenantLabelName: stage.Namespace,
+				capsule.TenantLabel:  tenantName,
+			},
+		},
+	}
+
+	if err = h.client.Create(ctx, ns); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			l.Info("Namespace already exists")
+
+			return nil
+		}
+
+		var statusErr apierrors.APIStatus
+		if errors.As(err, &statusErr) && (apierrors.IsForbidden(err) || apierrors.IsInvalid(err)) {
+			return fmt.Errorf("capsule rejected namespace %s in Tenant %s: %s: %w",
+				stage.Spec.Namespace, tenantName, statusErr.Status().Message, err)
+		}
+
+		return fmt.Errorf("failed to create namespace: %w", err)
+	}
+
+	l.Info("Namespace has been created")
+
+	return nil
+}
+
+func (h PutCapsuleNamespace) checkTenantQuota(ctx context.Context, tenantName string) error {
+	tenant := capsule.NewTenant(tenantName)
+	if err := h.client.Get(ctx, client.ObjectKey{Name: tenantName}, tenant); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("capsule Tenant %s doesn't exist", tenantName)
+		}
+
+		return fmt.Errorf("failed to get capsule Tenant %s: %w", tenantName, err)
+	}
+
+	quota, ok := capsule.NamespaceQuota(tenant)
+	if !ok {
+		return nil
+	}
+
+	if count := capsule.NamespaceCount(tenant); count >= quota {
+		return fmt.Errorf("capsule Tenant %s namespace quota is exceeded: %d of %d namespaces are used",
+			tenantName, count, quota)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/capsule"
+)
+
+func TestPutCapsuleNamespace_ServeRequest(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	stage := &cdPipeApi.Stage{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "stage-1",
+			Namespace: "krci",
+		},
+		Spec: cdPipeApi.StageSpec{
+			Namespace:   "krci-stage-1",
+			ClusterName: cdPipeApi.InCluster,
+		},
+	}
+
+	newTenant := func(quota, size int64) *unstructured.Unstructured {
+		tenant := capsule.NewTenant("edp-workload-krci")
+
+		if quota > 0 {
+			require.NoError(t, unstructured.SetNestedField(tenant.Object, quota, "spec", "namespaceOptions", "quota"))
+		}
+
+		require.NoError(t, unstructured.SetNestedField(tenant.Object, size, "status", "size"))
+
+		return tenant
+	}
+
+	tests := []struct {
+		name         string
+		objects      []client.Object
+		interceptors interceptor.Funcs
+		wantErr      require.ErrorAssertionFunc
+		wantAssert   func(t *testing.T, c client.Client)
+	}{
+		{
+			name:    "namespace is created in the tenant",
+			objects: []client.Object{newTenant(3, 2)},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {
+				ns := &corev1.Namespace{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "krci-stage-1"}, ns))
+				assert.Equal(t, map[string]string{
+					util.TenantLabelName: "krci",
+					capsule.TenantLabel:  "edp-workload-krci",
+				}, ns.Labels)
+			},
+		},
+		{
+			name:    "tenant without namespace quota",
+			objects: []client.Object{newTenant(0, 10)},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "krci-stage-1"}, &corev1.Namespace{}))
+			},
+		},
+		{
+			name:    "tenant namespace quota is exceeded",
+			objects: []client.Object{newTenant(2, 2)},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "namespace quota is exceeded: 2 of 2 namespaces are used")
+			},
+			wantAssert: func(t *testing.T, c client.Client) {
+				err := c.Get(context.Background(), client.ObjectKey{Name: "krci-stage-1"}, &corev1.Namespace{})
+				require.True(t, apierrors.IsNotFound(err))
+			},
+		},
+		{
+			name: "tenant doesn't exist",
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "capsule Tenant edp-workload-krci doesn't exist")
+			},
+			wantAssert: func(t *testing.T, c client.Client) {},
+		},
+		{
+			name: "namespace already exists in the tenant",
+			objects: []client.Object{
+				&corev1.Namespace{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:   "krci-stage-1",
+						Labels: map[string]string{capsule.TenantLabel: "edp-workload-krci"},
+					},
+				},
+			},
+			wantErr:    require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {},
+		},
+		{
+			name: "namespace belongs to another tenant",
+			objects: []client.Object{
+				&corev1.Namespace{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:   "krci-stage-1",
+						Labels: map[string]string{capsule.TenantLabel: "other"},
+					},
+				},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "belongs to another Capsule Tenant other")
+			},
+			wantAssert: func(t *testing.T, c client.Client) {},
+		},
+		{
+			name:    "capsule admission rejects namespace",
+			objects: []client.Object{newTenant(0, 0)},
+			interceptors: interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					return apierrors.NewForbidden(
+						schema.GroupResource{Resource: "namespaces"},
+						obj.GetName(),
+						assert.AnError,
+					)
+				},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "capsule rejected namespace krci-stage-1 in Tenant edp-workload-krci")
+				require.True(t, apierrors.IsForbidden(err))
+			},
+			wantAssert: func(t *testing.T, c client.Client) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				WithInterceptorFuncs(tt.interceptors).
+				Build()
+
+			err := PutCapsuleNamespace{client: c}.ServeRequest(
+				ctrl.LoggerInto(context.Background(), logr.Discard()),
+				stage.DeepCopy(),
+			)
+
+			tt.wantErr(t, err)
+			tt.wantAssert(t, c)
+		})
+	}
+}
@@ -3,6 +3,10 @@ package chain
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -12,9 +16,38 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/argocd"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/util/cluster"
+)
+
+// Keys of the Stage ConfigMap with the environment metadata.
+// Other keys of the ConfigMap are kept as is.
+const (
+	metadataCDPipelineKey        = "cdPipeline"
+	metadataStageKey             = "stage"
+	metadataOrderKey             = "order"
+	metadataClusterKey           = "cluster"
+	metadataNamespaceKey         = "namespace"
+	metadataSourceStageKey       = "sourceStage"
+	metadataLastPromotionTimeKey = "lastPromotionTime"
+	metadataImageTagSuffix       = ".imageTag"
+	metadataImageDigestSuffix    = ".imageDigest"
 )
 
+var metadataKeys = []string{
+	metadataCDPipelineKey,
+	metadataStageKey,
+	metadataOrderKey,
+	metadataClusterKey,
+	metadataNamespaceKey,
+	metadataSourceStageKey,
+	metadataLastPromotionTimeKey,
+}
+
 type PutConfigMap struct {
 	k8sClient client.Client
 }
@@ -23,14 +56,20 @@ func NewPutConfigMap(k8sClient client.Client) *PutConfigMap {
 	return &PutConfigMap{k8sClient: k8sClient}
 }
 
+// ServeRequest creates the Stage ConfigMap and keeps it filled with the Stage environment metadata.
 func (h *PutConfigMap) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
 	log := ctrl.LoggerFrom(ctx)
 
 	log.Info("Start putting ConfigMap", "configMapName", stage.Name)
 
+	metadata, err := getStageMetadata(ctx, h.k8sClient, stage)
+	if err != nil {
+		return err
+	}
+
 	cm := &corev1.ConfigMap{}
 
-	err := h.k8sClient.Get(
+	err = h.k8sClient.Get(
 		ctx,
 		types.NamespacedName{
 			Namespace: stage.Namespace,
@@ -43,7 +82,17 @@ func (h *PutConfigMap) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage)
 	}
 
 	if err == nil {
-		log.Info("ConfigMap already exists")
+		if !setStageMetadata(cm, metadata) {
+			log.Info("ConfigMap is up to date")
+
+			return nil
+		}
+
+		if err = h.k8sClient.Update(ctx, cm); err != nil {
+			return fmt.Errorf("failed to update ConfigMap: %w", err)
+		}
+
+		log.Info("ConfigMap has been updated")
 
 		return nil
 	}
@@ -53,6 +102,7 @@ func (h *PutConfigMap) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage)
 			Name:      stage.Name,
 			Namespace: stage.Namespace,
 		},
+		Data: metadata,
 	}
 
 	if err = controllerutil.SetControllerReference(stage, cm, h.k8sClient.Scheme()); err != nil {
@@ -67,3 +117,187 @@ func (h *PutConfigMap) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage)
 
 	return nil
 }
+
+// getStageMetadata returns the environment metadata of the Stage cluster target.
+// The metadata is best-effort: the source stage and the deployed images are omitted if they can't be resolved.
+func getStageMetadata(ctx context.Context, k8sClient client.Client, stage *cdPipeApi.Stage) (map[string]string, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	metadata := map[string]string{
+		metadataCDPipelineKey: stage.Spec.CdPipeline,
+		metadataStageKey:      stage.Spec.Name,
+		metadataOrderKey:      strconv.Itoa(stage.Spec.Order),
+		metadataClusterKey:    stage.Spec.ClusterName,
+		metadataNamespaceKey:  stage.Spec.Namespace,
+	}
+
+	sourceStage := ""
+
+	if !stage.IsFirst() {
+		var err error
+
+		if sourceStage, err = util.FindPreviousStageName(ctx, k8sClient, stage); err != nil {
+			log.Error(err, "Failed to get source stage")
+		} else {
+			metadata[metadataSourceStageKey] = sourceStage
+		}
+	}
+
+	images, err := argocd.NewArgoApplicationSetManager(k8sClient).GetStageImages(ctx, stage)
+	if err != nil {
+		log.Error(err, "Failed to get deployed images")
+	}
+
+	for codebase, image := range images {
+		metadata[codebase+metadataImageTagSuffix] = image.Tag
+
+		if image.Digest != "" {
+			metadata[codebase+metadataImageDigestSuffix] = image.Digest
+		}
+	}
+
+	lastPromotionTime, err := getLastPromotionTime(ctx, k8sClient, stage, sourceStage, images)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastPromotionTime != "" {
+		metadata[metadataLastPromotionTimeKey] = lastPromotionTime
+	}
+
+	return metadata, nil
+}
+
+// getLastPromotionTime returns the latest creation time of the tags deployed to the Stage.
+// The creation time is taken from the CodebaseImageStream the Stage takes the tag from:
+// like PutEnvironmentLabelToCodebaseImageStreams, the verified stream of the source stage is used
+// for the promoted applications and the pipeline input stream is used otherwise.
+func getLastPromotionTime(
+	ctx context.Context,
+	k8sClient client.Client,
+	stage *cdPipeApi.Stage,
+	sourceStage string,
+	images map[string]argocd.StageImage,
+) (string, error) {
+	if len(images) == 0 {
+		return "", nil
+	}
+
+	pipeline := &cdPipeApi.CDPipeline{}
+
+	err := k8sClient.Get(ctx, client.ObjectKey{Namespace: stage.Namespace, Name: stage.Spec.CdPipeline}, pipeline)
+	if err != nil {
+		// CDPipeline can be deleted before the Stage.
+		if k8sErrors.IsNotFound(err) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("failed to get CDPipeline: %w", err)
+	}
+
+	lastPromotionTime := ""
+
+	for _, name := range pipeline.Spec.InputDockerStreams {
+		stream, streamErr := getPromotionStream(ctx, k8sClient, stage, pipeline, name, sourceStage)
+		if streamErr != nil {
+			return "", streamErr
+		}
+
+		if stream == nil {
+			continue
+		}
+
+		image, ok := images[stream.Spec.Codebase]
+		if !ok {
+			continue
+		}
+
+		// Tag creation time is in RFC3339 format, so it can be compared as a string.
+		for _, tag := range stream.Spec.Tags {
+			if tag.Name == image.Tag && tag.Created > lastPromotionTime {
+				lastPromotionTime = tag.Created
+			}
+		}
+	}
+
+	return lastPromotionTime, nil
+}
+
+// getPromotionStream returns the CodebaseImageStream the Stage takes images of the input stream from.
+// It returns nil if the stream doesn't exist yet.
+func getPromotionStream(
+	ctx context.Context,
+	k8sClient client.Client,
+	stage *cdPipeApi.Stage,
+	pipeline *cdPipeApi.CDPipeline,
+	inputStream, sourceStage string,
+) (*codebaseApi.CodebaseImageStream, error) {
+	stream, err := cluster.GetCodebaseImageStreamByCodebaseBaseBranchName(ctx, k8sClient, inputStream, stage.Namespace)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to get input CodebaseImageStream", "stream", inputStream)
+
+		return nil, nil
+	}
+
+	if stage.IsFirst() || !slices.Contains(pipeline.Spec.ApplicationsToPromote, stream.Spec.Codebase) {
+		return stream, nil
+	}
+
+	// The verified stream can't be found without the source stage.
+	if sourceStage == "" {
+		return nil, nil
+	}
+
+	verified := &codebaseApi.CodebaseImageStream{}
+
+	err = k8sClient.Get(ctx, client.ObjectKey{
+		Namespace: stage.Namespace,
+		Name:      createCisName(pipeline.Name, sourceStage, stream.Spec.Codebase),
+	}, verified)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get verified CodebaseImageStream: %w", err)
+	}
+
+	return verified, nil
+}
+
+// setStageMetadata sets the metadata to the ConfigMap and removes the stale metadata keys.
+// It returns true if the ConfigMap has been changed.
+func setStageMetadata(cm *corev1.ConfigMap, metadata map[string]string) bool {
+	data := maps.Clone(cm.Data)
+	if data == nil {
+		data = make(map[string]string, len(metadata))
+	}
+
+	for k := range data {
+		if _, ok := metadata[k]; ok {
+			continue
+		}
+
+		if isStageMetadataKey(k) {
+			delete(data, k)
+		}
+	}
+
+	maps.Copy(data, metadata)
+
+	if maps.Equal(cm.Data, data) {
+		return false
+	}
+
+	cm.Data = data
+
+	return true
+}
+
+func isStageMetadataKey(key string) bool {
+	if slices.Contains(metadataKeys, key) {
+		return true
+	}
+
+	return strings.HasSuffix(key, metadataImageTagSuffix) || strings.HasSuffix(key, metadataImageDigestSuffix)
+}
@@ -2,18 +2,26 @@ package chain
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	argoApi "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/util/cluster"
 )
 
 func TestPutConfigMap_ServeRequest(t *testing.T) {
@@ -22,6 +30,120 @@ func TestPutConfigMap_ServeRequest(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, corev1.AddToScheme(scheme))
 	require.NoError(t, cdPipeApi.AddToScheme(scheme))
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, argoApi.AddToScheme(scheme))
+
+	metadataStage := &cdPipeApi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pipe-qa",
+			Namespace: "default",
+		},
+		Spec: cdPipeApi.StageSpec{
+			Name:        "qa",
+			CdPipeline:  "pipe",
+			Order:       1,
+			ClusterName: cdPipeApi.InCluster,
+			Namespace:   "default-pipe-qa",
+		},
+	}
+
+	metadataObjects := []client.Object{
+		&cdPipeApi.CDPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pipe",
+				Namespace: "default",
+			},
+			Spec: cdPipeApi.CDPipelineSpec{
+				Applications:          []string{"app", "web"},
+				InputDockerStreams:    []string{"app-main", "web-main"},
+				ApplicationsToPromote: []string{"app"},
+			},
+		},
+		&cdPipeApi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pipe-dev",
+				Namespace: "default",
+				Labels:    map[string]string{cdPipeApi.StageCdPipelineLabelName: "pipe"},
+			},
+			Spec: cdPipeApi.StageSpec{
+				Name:       "dev",
+				CdPipeline: "pipe",
+				Order:      0,
+			},
+		},
+		&argoApi.ApplicationSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pipe",
+				Namespace: "default",
+			},
+			Spec: argoApi.ApplicationSetSpec{
+				Generators: []argoApi.ApplicationSetGenerator{
+					{
+						List: &argoApi.ListGenerator{
+							Elements: []apiextensionsv1.JSON{
+								{Raw: []byte(`{"stage":"qa","codebase":"app","imageTag":"1.0.1","imageDigest":"sha256:abc","cluster":"in-cluster"}`)},
+								{Raw: []byte(`{"stage":"qa","codebase":"web","imageTag":"NaN","cluster":"in-cluster"}`)},
+								{Raw: []byte(`{"stage":"dev","codebase":"app","imageTag":"1.0.2","cluster":"in-cluster"}`)},
+							},
+						},
+					},
+				},
+			},
+		},
+		&codebaseApi.CodebaseImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "app-main",
+				Namespace: "default",
+				Labels:    map[string]string{cluster.CodebaseBranchLabel: "app-main"},
+			},
+			Spec: codebaseApi.CodebaseImageStreamSpec{
+				Codebase: "app",
+				Tags: []codebaseApi.Tag{
+					{Name: "1.0.2", Created: "2024-01-05T10:00:00Z"},
+					{Name: "1.0.3", Created: "2024-01-10T10:00:00Z"},
+				},
+			},
+		},
+		&codebaseApi.CodebaseImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-main",
+				Namespace: "default",
+				Labels:    map[string]string{cluster.CodebaseBranchLabel: "web-main"},
+			},
+			Spec: codebaseApi.CodebaseImageStreamSpec{
+				Codebase: "web",
+				Tags: []codebaseApi.Tag{
+					{Name: "0.1.0", Created: "2024-01-01T12:00:00Z"},
+				},
+			},
+		},
+		&codebaseApi.CodebaseImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pipe-dev-app-verified",
+				Namespace: "default",
+			},
+			Spec: codebaseApi.CodebaseImageStreamSpec{
+				Codebase: "app",
+				Tags: []codebaseApi.Tag{
+					{Name: "1.0.0", Created: "2024-01-01T10:00:00Z"},
+					{Name: "1.0.1", Created: "2024-01-02T10:00:00Z"},
+					{Name: "1.0.2", Created: "2024-01-06T10:00:00Z"},
+				},
+			},
+		},
+	}
+
+	wantMetadata := map[string]string{
+		"cdPipeline":        "pipe",
+		"stage":             "qa",
+		"order":             "1",
+		"cluster":           cdPipeApi.InCluster,
+		"namespace":         "default-pipe-qa",
+		"sourceStage":       "dev",
+		"lastPromotionTime": "2024-01-02T10:00:00Z",
+		"app.imageTag":      "1.0.1",
+		"app.imageDigest":   "sha256:abc",
+	}
 
 	tests := []struct {
 		name    string
@@ -74,6 +196,137 @@ func TestPutConfigMap_ServeRequest(t *testing.T) {
 			},
 			wantErr: require.NoError,
 		},
+		{
+			name:  "configmap is created with stage metadata",
+			stage: metadataStage.DeepCopy(),
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(metadataObjects...).Build()
+			},
+			wantErr: require.NoError,
+			want: func(t *testing.T, stage *cdPipeApi.Stage, cl client.Client) {
+				cm := &corev1.ConfigMap{}
+				require.NoError(t, cl.Get(
+					context.Background(),
+					client.ObjectKey{Namespace: stage.Namespace, Name: stage.Name},
+					cm,
+				))
+
+				assert.Equal(t, wantMetadata, cm.Data)
+			},
+		},
+		{
+			name: "first stage takes the promotion time from the deployed input stream tags",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipe-dev",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					Name:        "dev",
+					CdPipeline:  "pipe",
+					Order:       0,
+					ClusterName: cdPipeApi.InCluster,
+					Namespace:   "default-pipe-dev",
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(metadataObjects...).Build()
+			},
+			wantErr: require.NoError,
+			want: func(t *testing.T, stage *cdPipeApi.Stage, cl client.Client) {
+				cm := &corev1.ConfigMap{}
+				require.NoError(t, cl.Get(
+					context.Background(),
+					client.ObjectKey{Namespace: stage.Namespace, Name: stage.Name},
+					cm,
+				))
+
+				assert.Equal(t, "2024-01-05T10:00:00Z", cm.Data["lastPromotionTime"])
+				assert.NotContains(t, cm.Data, "sourceStage")
+			},
+		},
+		{
+			name:  "unresolved metadata is omitted",
+			stage: metadataStage.DeepCopy(),
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(metadataObjects[0]).
+					WithInterceptorFuncs(interceptor.Funcs{
+						Get: func(
+							ctx context.Context,
+							cl client.WithWatch,
+							key client.ObjectKey,
+							obj client.Object,
+							opts ...client.GetOption,
+						) error {
+							if _, ok := obj.(*argoApi.ApplicationSet); ok {
+								return errors.New("failed to get ApplicationSet")
+							}
+
+							return cl.Get(ctx, key, obj, opts...)
+						},
+					}).
+					Build()
+			},
+			wantErr: require.NoError,
+			want: func(t *testing.T, stage *cdPipeApi.Stage, cl client.Client) {
+				cm := &corev1.ConfigMap{}
+				require.NoError(t, cl.Get(
+					context.Background(),
+					client.ObjectKey{Namespace: stage.Namespace, Name: stage.Name},
+					cm,
+				))
+
+				assert.Equal(t, map[string]string{
+					"cdPipeline": "pipe",
+					"stage":      "qa",
+					"order":      "1",
+					"cluster":    cdPipeApi.InCluster,
+					"namespace":  "default-pipe-qa",
+				}, cm.Data)
+			},
+		},
+		{
+			name:  "stale metadata is removed and other keys are kept",
+			stage: metadataStage.DeepCopy(),
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(metadataObjects...).
+					WithObjects(
+						&corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "pipe-qa",
+								Namespace: "default",
+							},
+							Data: map[string]string{
+								"stage":           "old",
+								"old.imageTag":    "0.0.1",
+								"old.imageDigest": "sha256:old",
+								"custom":          "value",
+							},
+						},
+					).
+					Build()
+			},
+			wantErr: require.NoError,
+			want: func(t *testing.T, stage *cdPipeApi.Stage, cl client.Client) {
+				cm := &corev1.ConfigMap{}
+				require.NoError(t, cl.Get(
+					context.Background(),
+					client.ObjectKey{Namespace: stage.Namespace, Name: stage.Name},
+					cm,
+				))
+
+				want := map[string]string{"custom": "value"}
+				for k, v := range wantMetadata {
+					want[k] = v
+				}
+
+				assert.Equal(t, want, cm.Data)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -3,19 +3,30 @@ package chain
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	projectApi "github.com/openshift/api/project/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
 	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
 )
 
+// projectRequesterAnnotation is an OpenShift annotation with the user who requested the project.
+const projectRequesterAnnotation = "openshift.io/requester"
+
 // PutOpenshiftProject is a handler that creates an openshift project for a stage.
+// The project is created through the ProjectRequest, so the cluster project request template is applied.
+// The template can't be chosen per project, because ProjectRequest has no template field
+// and OpenShift takes it from the cluster-wide project.config.openshift.io/cluster resource.
 type PutOpenshiftProject struct {
-	client multiClusterClient
+	client         multiClusterClient
+	internalClient client.Client
 }
 
 // ServeRequest creates a project for a stage.
@@ -25,6 +36,11 @@ func (c PutOpenshiftProject) ServeRequest(ctx context.Context, stage *cdPipeApi.
 
 	logger.Info("Try to create project")
 
+	pipeline, err := util.GetCdPipeline(c.internalClient, stage)
+	if err != nil {
+		return fmt.Errorf("failed to get CDPipeline of stage: %w", err)
+	}
+
 	project := &projectApi.ProjectRequest{
 		ObjectMeta: metaV1.ObjectMeta{
 			Name: projectName,
@@ -32,19 +48,71 @@ func (c PutOpenshiftProject) ServeRequest(ctx context.Context, stage *cdPipeApi.
 				util.TenantLabelName: stage.Namespace,
 			},
 		},
+		DisplayName: fmt.Sprintf("%s / %s", pipeline.Spec.Name, stage.Spec.Name),
+		Description: projectDescription(pipeline, stage),
 	}
 
-	if err := c.client.Create(context.TODO(), project); err != nil {
-		if apierrors.IsAlreadyExists(err) {
-			logger.Info("Project already exists")
-
-			return nil
+	if err = c.client.Create(ctx, project); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create project: %w", err)
 		}
 
-		return fmt.Errorf("failed to create project: %w", err)
+		logger.Info("Project already exists")
+	} else {
+		logger.Info("Project has been created")
+	}
+
+	return c.setRequester(ctx, projectName, stage)
+}
+
+// setRequester sets the requester annotation to the project namespace.
+// The project is requested by the operator, so the annotation is replaced with the configured requester
+// or the user who created the Stage. Projects are read-only, so the annotation is set on the namespace.
+func (c PutOpenshiftProject) setRequester(ctx context.Context, projectName string, stage *cdPipeApi.Stage) error {
+	requester := platform.GetOpenshiftProjectRequester()
+	if requester == "" {
+		requester = stage.GetAnnotations()[cdPipeApi.CreatedByAnnotation]
+	}
+
+	if requester == "" {
+		return nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: projectName}, ns); err != nil {
+		return fmt.Errorf("failed to get project namespace: %w", err)
+	}
+
+	if ns.Annotations[projectRequesterAnnotation] == requester {
+		return nil
+	}
+
+	patch := client.MergeFrom(ns.DeepCopy())
+
+	if ns.Annotations == nil {
+		ns.Annotations = make(map[string]string, 1)
+	}
+
+	ns.Annotations[projectRequesterAnnotation] = requester
+
+	if err := c.client.Patch(ctx, ns, patch); err != nil {
+		return fmt.Errorf("failed to set project requester: %w", err)
 	}
 
-	logger.Info("Project has been created")
+	ctrl.LoggerFrom(ctx).Info("Project requester has been set", "project", projectName, "requester", requester)
 
 	return nil
 }
+
+// projectDescription joins the CDPipeline and Stage descriptions.
+func projectDescription(pipeline *cdPipeApi.CDPipeline, stage *cdPipeApi.Stage) string {
+	parts := make([]string, 0, 2)
+
+	for _, d := range []string{pipeline.Spec.Description, stage.Spec.Description} {
+		if d = strings.TrimSpace(d); d != "" {
+			parts = append(parts, d)
+		}
+	}
+
+	return strings.Join(parts, ". ")
+}
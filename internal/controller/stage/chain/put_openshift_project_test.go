@@ -6,91 +6,211 @@ import (
 
 	"github.com/go-logr/logr"
 	projectApi "github.com/openshift/api/project/v1"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
 )
 
-func TestPutOpenshiftProject_ServeRequest(t *testing.T) {
-	t.Parallel()
+// projectRequestInterceptor emulates OpenShift that creates a project namespace from the ProjectRequest
+// and rejects changes of the Project, because it is a read-only view of the namespace.
+var projectRequestInterceptor = interceptor.Funcs{
+	Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+		if err := c.Create(ctx, obj, opts...); err != nil {
+			return err
+		}
+
+		if _, ok := obj.(*projectApi.ProjectRequest); !ok {
+			return nil
+		}
+
+		return c.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name: obj.GetName(),
+				Annotations: map[string]string{
+					projectRequesterAnnotation: "system:serviceaccount:default:edp-cd-pipeline-operator",
+				},
+			},
+		})
+	},
+	Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+		if _, ok := obj.(*projectApi.Project); ok {
+			return k8sErrors.NewMethodNotSupported(projectApi.Resource("projects"), "update")
+		}
+
+		return c.Update(ctx, obj, opts...)
+	},
+	Patch: func(
+		ctx context.Context,
+		c client.WithWatch,
+		obj client.Object,
+		patch client.Patch,
+		opts ...client.PatchOption,
+	) error {
+		if _, ok := obj.(*projectApi.Project); ok {
+			return k8sErrors.NewMethodNotSupported(projectApi.Resource("projects"), "patch")
+		}
+
+		return c.Patch(ctx, obj, patch, opts...)
+	},
+}
 
+func TestPutOpenshiftProject_ServeRequest(t *testing.T) {
 	scheme := runtime.NewScheme()
 
 	require.NoError(t, projectApi.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, cdPipeApi.AddToScheme(scheme))
+
+	pipeline := &cdPipeApi.CDPipeline{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "mypipeline",
+			Namespace: "default",
+		},
+		Spec: cdPipeApi.CDPipelineSpec{
+			Name:        "mypipeline",
+			Description: "Payments platform",
+		},
+	}
+
+	newStage := func(annotations map[string]string) *cdPipeApi.Stage {
+		return &cdPipeApi.Stage{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:        "stage-1",
+				Namespace:   "default",
+				Annotations: annotations,
+			},
+			Spec: cdPipeApi.StageSpec{
+				Name:        "dev",
+				CdPipeline:  "mypipeline",
+				Description: "Development environment",
+				Namespace:   "stage-1-ns",
+			},
+		}
+	}
 
 	tests := []struct {
 		name       string
 		stage      *cdPipeApi.Stage
+		prepare    func(t *testing.T)
 		objects    []client.Object
 		wantErr    require.ErrorAssertionFunc
 		wantAssert func(t *testing.T, c client.Client, s *cdPipeApi.Stage)
 	}{
 		{
-			name: "creation of project is successful",
-			stage: &cdPipeApi.Stage{
-				ObjectMeta: metaV1.ObjectMeta{
-					Name:      "stage-1",
-					Namespace: "default",
-				},
-				Spec: cdPipeApi.StageSpec{
-					Namespace: "stage-1-ns",
-				},
-			},
+			name:    "creation of project is successful",
+			stage:   newStage(map[string]string{cdPipeApi.CreatedByAnnotation: "jane"}),
+			prepare: func(t *testing.T) {},
 			wantErr: require.NoError,
 			wantAssert: func(t *testing.T, c client.Client, s *cdPipeApi.Stage) {
+				request := &projectApi.ProjectRequest{}
 				require.NoError(
 					t,
 					c.Get(
 						context.Background(),
-						types.NamespacedName{Name: s.Spec.Namespace}, &projectApi.ProjectRequest{},
+						types.NamespacedName{Name: s.Spec.Namespace}, request,
 					),
 				)
+				assert.Equal(t, "mypipeline / dev", request.DisplayName)
+				assert.Equal(t, "Payments platform. Development environment", request.Description)
+
+				ns := &corev1.Namespace{}
+				require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: s.Spec.Namespace}, ns))
+				assert.Equal(t, "jane", ns.Annotations[projectRequesterAnnotation])
 			},
 		},
 		{
-			name: "project already exists",
-			stage: &cdPipeApi.Stage{
-				ObjectMeta: metaV1.ObjectMeta{
-					Name:      "stage-1",
-					Namespace: "default",
-				},
-				Spec: cdPipeApi.StageSpec{
-					Namespace: "stage-1-ns",
-				},
+			name:  "requester is configured",
+			stage: newStage(map[string]string{cdPipeApi.CreatedByAnnotation: "jane"}),
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.OpenshiftProjectRequester, "platform-team")
+			},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client, s *cdPipeApi.Stage) {
+				ns := &corev1.Namespace{}
+				require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: s.Spec.Namespace}, ns))
+				assert.Equal(t, "platform-team", ns.Annotations[projectRequesterAnnotation])
 			},
+		},
+		{
+			name:    "requester is unknown",
+			stage:   newStage(nil),
+			prepare: func(t *testing.T) {},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, c client.Client, s *cdPipeApi.Stage) {
+				ns := &corev1.Namespace{}
+				require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: s.Spec.Namespace}, ns))
+				assert.Equal(t,
+					"system:serviceaccount:default:edp-cd-pipeline-operator",
+					ns.Annotations[projectRequesterAnnotation],
+				)
+			},
+		},
+		{
+			name:    "project already exists",
+			stage:   newStage(map[string]string{cdPipeApi.CreatedByAnnotation: "jane"}),
+			prepare: func(t *testing.T) {},
 			objects: []client.Object{
 				&projectApi.ProjectRequest{
 					ObjectMeta: metaV1.ObjectMeta{
 						Name: "stage-1-ns",
 					},
 				},
+				&corev1.Namespace{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name: "stage-1-ns",
+						Annotations: map[string]string{
+							projectRequesterAnnotation: "system:serviceaccount:default:edp-cd-pipeline-operator",
+						},
+					},
+				},
 			},
 			wantErr: require.NoError,
 			wantAssert: func(t *testing.T, c client.Client, s *cdPipeApi.Stage) {
-				require.NoError(
-					t,
-					c.Get(
-						context.Background(),
-						types.NamespacedName{Name: s.Spec.Namespace}, &projectApi.ProjectRequest{},
-					),
-				)
+				ns := &corev1.Namespace{}
+				require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: s.Spec.Namespace}, ns))
+				assert.Equal(t, "jane", ns.Annotations[projectRequesterAnnotation])
+			},
+		},
+		{
+			name: "CDPipeline doesn't exist",
+			stage: func() *cdPipeApi.Stage {
+				s := newStage(nil)
+				s.Spec.CdPipeline = "unknown"
+
+				return s
+			}(),
+			prepare: func(t *testing.T) {},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to get CDPipeline of stage")
 			},
+			wantAssert: func(t *testing.T, c client.Client, s *cdPipeApi.Stage) {},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
+			tt.prepare(t)
 
-			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				WithInterceptorFuncs(projectRequestInterceptor).
+				Build()
 
 			c := PutOpenshiftProject{
-				client: k8sClient,
+				client:         k8sClient,
+				internalClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).Build(),
 			}
 
 			err := c.ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), tt.stage)
@@ -99,3 +219,43 @@ func TestPutOpenshiftProject_ServeRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_projectDescription(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                string
+		pipelineDescription string
+		stageDescription    string
+		want                string
+	}{
+		{
+			name:                "both descriptions are set",
+			pipelineDescription: "Payments",
+			stageDescription:    "Development",
+			want:                "Payments. Development",
+		},
+		{
+			name:             "only stage description is set",
+			stageDescription: " Development ",
+			want:             "Development",
+		},
+		{
+			name: "descriptions are empty",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := projectDescription(
+				&cdPipeApi.CDPipeline{Spec: cdPipeApi.CDPipelineSpec{Description: tt.pipelineDescription}},
+				&cdPipeApi.Stage{Spec: cdPipeApi.StageSpec{Description: tt.stageDescription}},
+			)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/hnc"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
+)
+
+// +kubebuilder:rbac:groups=hnc.x-k8s.io,namespace=placeholder,resources=subnamespaceanchors,verbs=get;list;create;delete
+
+// PutSubnamespaceAnchor is a handler that creates a Stage namespace as an HNC subnamespace of the tenant namespace.
+type PutSubnamespaceAnchor struct {
+	client multiClusterClient
+}
+
+// ServeRequest creates a SubnamespaceAnchor in the parent namespace and waits for the subnamespace.
+func (h PutSubnamespaceAnchor) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
+	parent, err := platform.GetHNCParentNamespace(stage.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get HNC parent namespace: %w", err)
+	}
+
+	l := ctrl.LoggerFrom(ctx).WithValues("namespace", stage.Spec.Namespace, "parent", parent)
+
+	anchor := &hnc.SubnamespaceAnchor{}
+
+	err = h.client.Get(ctx, client.ObjectKey{Name: stage.Spec.Namespace, Namespace: parent}, anchor)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get subnamespace anchor: %w", err)
+	}
+
+	if apierrors.IsNotFound(err) {
+		l.Info("Creating subnamespace anchor")
+
+		anchor = &hnc.SubnamespaceAnchor{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      stage.Spec.Namespace,
+				Namespace: parent,
+			},
+			Spec: hnc.SubnamespaceAnchorSpec{
+				Labels: []hnc.MetaKVP{
+					{
+						Key:   util.TenantLabelName,
+						Value: stage.Namespace,
+					},
+				},
+			},
+		}
+
+		if err = h.client.Create(ctx, anchor); err != nil {
+			return fmt.Errorf("failed to create subnamespace anchor: %w", err)
+		}
+
+		l.Info("Subnamespace anchor has been created")
+	}
+
+	// HNC creates the subnamespace asynchronously.
+	// The following handlers configure the namespace, so it should exist.
+	if anchor.Status.Status != hnc.AnchorOk {
+		return fmt.Errorf("subnamespace %s of %s is not ready, anchor status %q",
+			stage.Spec.Namespace, parent, anchor.Status.Status)
+	}
+
+	l.Info("Subnamespace is ready")
+
+	return nil
+}
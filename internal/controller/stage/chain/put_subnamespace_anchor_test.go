@@ -0,0 +1,140 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/hnc"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
+)
+
+func TestPutSubnamespaceAnchor_ServeRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, hnc.AddToScheme(scheme))
+
+	stage := &cdPipeApi.Stage{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "stage-1",
+			Namespace: "krci",
+		},
+		Spec: cdPipeApi.StageSpec{
+			Namespace:   "krci-stage-1",
+			ClusterName: cdPipeApi.InCluster,
+		},
+	}
+
+	withParent := func(t *testing.T) {
+		t.Setenv(platform.HNCParentNamespace, "krci-stages")
+	}
+
+	tests := []struct {
+		name       string
+		prepare    func(t *testing.T)
+		objects    []client.Object
+		wantErr    require.ErrorAssertionFunc
+		wantAssert func(t *testing.T, c client.Client)
+	}{
+		{
+			name:    "anchor is created in the parent namespace",
+			prepare: withParent,
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "is not ready")
+			},
+			wantAssert: func(t *testing.T, c client.Client) {
+				anchor := &hnc.SubnamespaceAnchor{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{
+					Name:      "krci-stage-1",
+					Namespace: "krci-stages",
+				}, anchor))
+				assert.Equal(t, []hnc.MetaKVP{{Key: util.TenantLabelName, Value: "krci"}}, anchor.Spec.Labels)
+			},
+		},
+		{
+			name: "parent namespace is not set",
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.HNCParentNamespace, "")
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "HNC_PARENT_NAMESPACE is not set")
+			},
+			wantAssert: func(t *testing.T, c client.Client) {
+				anchors := &hnc.SubnamespaceAnchorList{}
+				require.NoError(t, c.List(context.Background(), anchors))
+				assert.Empty(t, anchors.Items)
+			},
+		},
+		{
+			name: "parent namespace is the tenant namespace",
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.HNCParentNamespace, "krci")
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "can't be the tenant namespace krci")
+			},
+			wantAssert: func(t *testing.T, c client.Client) {},
+		},
+		{
+			name:    "subnamespace is ready",
+			prepare: withParent,
+			objects: []client.Object{
+				&hnc.SubnamespaceAnchor{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "krci-stage-1",
+						Namespace: "krci-stages",
+					},
+					Status: hnc.SubnamespaceAnchorStatus{Status: hnc.AnchorOk},
+				},
+			},
+			wantErr:    require.NoError,
+			wantAssert: func(t *testing.T, c client.Client) {},
+		},
+		{
+			name:    "subnamespace conflicts with existing namespace",
+			prepare: withParent,
+			objects: []client.Object{
+				&hnc.SubnamespaceAnchor{
+					ObjectMeta: metaV1.ObjectMeta{
+						Name:      "krci-stage-1",
+						Namespace: "krci-stages",
+					},
+					Status: hnc.SubnamespaceAnchorStatus{Status: "Conflict"},
+				},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), `anchor status "Conflict"`)
+			},
+			wantAssert: func(t *testing.T, c client.Client) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+
+			err := PutSubnamespaceAnchor{client: c}.ServeRequest(
+				ctrl.LoggerInto(context.Background(), logr.Discard()),
+				stage.DeepCopy(),
+			)
+
+			tt.wantErr(t, err)
+			tt.wantAssert(t, c)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/handler"
+)
+
+// clusterTargetChain is a chain of handlers that should be applied to a single cluster target.
+type clusterTargetChain struct {
+	target cdPipeApi.ClusterTarget
+	chain  handler.CdStageHandler
+}
+
+// ServeClusterTargets is a stage chain element that applies cluster specific handlers to every Stage cluster target.
+type ServeClusterTargets struct {
+	targets []clusterTargetChain
+}
+
+// ServeRequest runs cluster specific handlers for every cluster target.
+// All targets are processed even if some of them fail, the errors are aggregated.
+func (h ServeClusterTargets) ServeRequest(ctx context.Context, stage *cdPipeApi.Stage) error {
+	var errs []error
+
+	for _, t := range h.targets {
+		logger := ctrl.LoggerFrom(ctx).WithValues("cluster", t.target.Name, "target-ns", t.target.Namespace)
+		logger.Info("Processing cluster target")
+
+		if err := t.chain.ServeRequest(ctrl.LoggerInto(ctx, logger), stage.ForClusterTarget(t.target)); err != nil {
+			errs = append(errs, fmt.Errorf("cluster %s: %w", t.target.Name, err))
+
+			continue
+		}
+
+		logger.Info("Cluster target has been processed")
+	}
+
+	return errors.Join(errs...)
+}
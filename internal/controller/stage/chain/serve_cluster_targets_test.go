@@ -0,0 +1,107 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+type targetRecorder struct {
+	served    map[string]string
+	failOnCls string
+}
+
+func (r *targetRecorder) ServeRequest(_ context.Context, stage *cdPipeApi.Stage) error {
+	r.served[stage.Spec.ClusterName] = stage.Spec.Namespace
+
+	if stage.Spec.ClusterName == r.failOnCls {
+		return errors.New("cluster is unavailable")
+	}
+
+	return nil
+}
+
+func TestServeClusterTargets_ServeRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		failOnCls  string
+		wantErr    require.ErrorAssertionFunc
+		wantServed map[string]string
+	}{
+		{
+			name: "should serve all cluster targets",
+			wantErr: func(t require.TestingT, err error, _ ...interface{}) {
+				require.NoError(t, err)
+			},
+			wantServed: map[string]string{
+				cdPipeApi.InCluster: "default-dev",
+				"eu-cluster":        "default-dev",
+				"us-cluster":        "us-dev",
+			},
+		},
+		{
+			name:      "should serve all cluster targets even if one of them fails",
+			failOnCls: "eu-cluster",
+			wantErr: func(t require.TestingT, err error, _ ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cluster eu-cluster: cluster is unavailable")
+			},
+			wantServed: map[string]string{
+				cdPipeApi.InCluster: "default-dev",
+				"eu-cluster":        "default-dev",
+				"us-cluster":        "us-dev",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stage := &cdPipeApi.Stage{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "dev",
+					Namespace: "default",
+				},
+				Spec: cdPipeApi.StageSpec{
+					ClusterName: cdPipeApi.InCluster,
+					Namespace:   "default-dev",
+					Clusters: []cdPipeApi.ClusterTarget{
+						{Name: "eu-cluster"},
+						{Name: "us-cluster", Namespace: "us-dev"},
+					},
+				},
+			}
+
+			recorder := &targetRecorder{
+				served:    map[string]string{},
+				failOnCls: tt.failOnCls,
+			}
+
+			targets := make([]clusterTargetChain, 0, len(stage.Spec.Clusters)+1)
+			for _, target := range stage.GetClusterTargets() {
+				targets = append(targets, clusterTargetChain{target: target, chain: recorder})
+			}
+
+			err := ServeClusterTargets{targets: targets}.ServeRequest(
+				ctrl.LoggerInto(context.Background(), logr.Discard()),
+				stage,
+			)
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.wantServed, recorder.served)
+			assert.Equal(t, cdPipeApi.InCluster, stage.Spec.ClusterName, "original stage should not be modified")
+			assert.Equal(t, "default-dev", stage.Spec.Namespace, "original stage should not be modified")
+		})
+	}
+}
@@ -1,14 +1,19 @@
 package util
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"text/template"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
 	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/helper"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/externalsecrets"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/util/cluster"
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/util/consts"
 )
@@ -32,6 +37,55 @@ func GetCdPipeline(k8sClient client.Client, stage *cdPipeApi.Stage) (*cdPipeApi.
 	return pipeline, nil
 }
 
+// GetImagePullSecrets returns the image pull secrets of the Stage namespaces.
+// If the Stage doesn't define them, the secrets from the operator configuration are used.
+func GetImagePullSecrets(stage *cdPipeApi.Stage) []string {
+	if len(stage.Spec.ImagePullSecrets) > 0 {
+		return stage.Spec.ImagePullSecrets
+	}
+
+	return platform.GetImagePullSecrets()
+}
+
+// GetSecretStoreTemplateName returns the name of the ConfigMap with the Stage SecretStore template.
+// If the Stage doesn't reference the template, the default tenant template name is returned.
+func GetSecretStoreTemplateName(stage *cdPipeApi.Stage) string {
+	if stage.Spec.SecretStoreTemplate != "" {
+		return stage.Spec.SecretStoreTemplate
+	}
+
+	return externalsecrets.DefaultTemplateName
+}
+
+// GetSecretManager returns the secret manager of the Stage.
+// The CDPipeline annotation overrides the secret manager of the tenant.
+func GetSecretManager(ctx context.Context, k8sClient client.Client, stage *cdPipeApi.Stage) (string, error) {
+	if stage.Spec.CdPipeline != "" {
+		pipeline := &cdPipeApi.CDPipeline{}
+
+		err := k8sClient.Get(ctx, client.ObjectKey{Namespace: stage.Namespace, Name: stage.Spec.CdPipeline}, pipeline)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get CDPipeline: %w", err)
+		}
+
+		// CDPipeline can be deleted before the Stage, so the tenant secret manager is used.
+		if secretManager := pipeline.GetAnnotations()[cdPipeApi.SecretManagerAnnotation]; secretManager != "" {
+			if err = platform.ValidateSecretManager(secretManager); err != nil {
+				return "", fmt.Errorf("invalid %s annotation of CDPipeline: %w", cdPipeApi.SecretManagerAnnotation, err)
+			}
+
+			return secretManager, nil
+		}
+	}
+
+	secretManager, err := platform.GetSecretManager(ctx, k8sClient, stage.Namespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tenant secret manager: %w", err)
+	}
+
+	return secretManager, nil
+}
+
 func FindPreviousStageName(ctx context.Context, k8sClient client.Client, stage *cdPipeApi.Stage) (string, error) {
 	if stage.IsFirst() {
 		return "", errors.New("can't get previous stage from first stage")
@@ -62,3 +116,38 @@ func FindPreviousStageName(ctx context.Context, k8sClient client.Client, stage *
 func GenerateNamespaceName(stage *cdPipeApi.Stage) string {
 	return fmt.Sprintf("%s-%s", stage.Namespace, stage.Name)
 }
+
+// NamespaceTemplateData contains the data available in the Stage namespace template.
+type NamespaceTemplateData struct {
+	// Tenant is the namespace where the Stage is created.
+	Tenant string
+	// Name is the name of the Stage resource.
+	Name string
+	// CDPipeline is the name of the CDPipeline.
+	CDPipeline string
+	// Stage is the name of the stage from spec.
+	Stage string
+	// Cluster is the name of the cluster where the application will be deployed.
+	Cluster string
+}
+
+// RenderNamespaceName renders the Stage target namespace name using the given template.
+func RenderNamespaceName(stage *cdPipeApi.Stage, namespaceTemplate string) (string, error) {
+	tmpl, err := template.New("namespace").Option("missingkey=error").Parse(namespaceTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse namespace template: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, NamespaceTemplateData{
+		Tenant:     stage.Namespace,
+		Name:       stage.Name,
+		CDPipeline: stage.Spec.CdPipeline,
+		Stage:      stage.Spec.Name,
+		Cluster:    stage.Spec.ClusterName,
+	}); err != nil {
+		return "", fmt.Errorf("failed to render namespace template: %w", err)
+	}
+
+	return buf.String(), nil
+}
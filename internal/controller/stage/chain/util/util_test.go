@@ -5,12 +5,14 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	k8sApi "k8s.io/api/rbac/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
 )
 
 const (
@@ -255,3 +257,96 @@ func TestGenerateNamespaceName(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderNamespaceName(t *testing.T) {
+	t.Parallel()
+
+	stage := &cdPipeApi.Stage{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "pipeline-dev",
+			Namespace: "krci",
+		},
+		Spec: cdPipeApi.StageSpec{
+			Name:        "dev",
+			CdPipeline:  "pipeline",
+			ClusterName: cdPipeApi.InCluster,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			name:     "default template",
+			template: "{{ .Tenant }}-{{ .CDPipeline }}-{{ .Stage }}",
+			want:     "krci-pipeline-dev",
+			wantErr:  require.NoError,
+		},
+		{
+			name:     "custom template",
+			template: "{{ .Cluster }}-{{ .Name }}",
+			want:     "in-cluster-pipeline-dev",
+			wantErr:  require.NoError,
+		},
+		{
+			name:     "invalid template",
+			template: "{{ .Tenant",
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to parse namespace template")
+			},
+		},
+		{
+			name:     "unknown field",
+			template: "{{ .Unknown }}",
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to render namespace template")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := RenderNamespaceName(stage, tt.template)
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetImagePullSecrets(t *testing.T) {
+	t.Setenv(platform.ImagePullSecrets, "regcred,quay-creds")
+
+	tests := []struct {
+		name  string
+		stage *cdPipeApi.Stage
+		want  []string
+	}{
+		{
+			name: "stage image pull secrets are used",
+			stage: &cdPipeApi.Stage{
+				Spec: cdPipeApi.StageSpec{
+					ImagePullSecrets: []string{"harbor-creds"},
+				},
+			},
+			want: []string{"harbor-creds"},
+		},
+		{
+			name:  "image pull secrets from configuration are used",
+			stage: &cdPipeApi.Stage{},
+			want:  []string{"regcred", "quay-creds"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetImagePullSecrets(tt.stage))
+		})
+	}
+}
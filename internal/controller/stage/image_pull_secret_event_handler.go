@@ -0,0 +1,98 @@
+package stage
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+)
+
+var _ handler.EventHandler = &ImagePullSecretEventHandler{}
+
+// ImagePullSecretEventHandler is a handler for Secret events,
+// which triggers reconciliation of all stages that use the Secret as an image pull secret.
+type ImagePullSecretEventHandler struct {
+	client client.Client
+	log    logr.Logger
+}
+
+// NewImagePullSecretEventHandler creates a new ImagePullSecretEventHandler.
+func NewImagePullSecretEventHandler(c client.Client, log logr.Logger) *ImagePullSecretEventHandler {
+	return &ImagePullSecretEventHandler{client: c, log: log}
+}
+
+// Create triggers stages to copy the image pull secret.
+func (h *ImagePullSecretEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	h.enqueueStages(ctx, evt.Object, q)
+}
+
+// Update triggers stages to sync the rotated image pull secret.
+func (h *ImagePullSecretEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	h.enqueueStages(ctx, evt.ObjectNew, q)
+}
+
+// nolint
+// Delete does nothing, skip event.
+func (h *ImagePullSecretEventHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+// nolint
+// Generic does nothing, skip event.
+func (h *ImagePullSecretEventHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *ImagePullSecretEventHandler) enqueueStages(
+	ctx context.Context,
+	obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	if obj == nil {
+		h.log.Info("Object is nil")
+		return
+	}
+
+	if _, ok := obj.(*corev1.Secret); !ok {
+		h.log.Info("Object is not Secret")
+		return
+	}
+
+	stages := &cdPipeApi.StageList{}
+	if err := h.client.List(
+		ctx,
+		stages,
+		client.InNamespace(obj.GetNamespace()),
+		client.Limit(clientLimit),
+	); err != nil {
+		h.log.Error(err, "unable to get stages for image pull secret", "secret", obj.GetName())
+		return
+	}
+
+	for i := range stages.Items {
+		if !slices.Contains(util.GetImagePullSecrets(&stages.Items[i]), obj.GetName()) {
+			continue
+		}
+
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: stages.Items[i].GetNamespace(),
+			Name:      stages.Items[i].GetName(),
+		}})
+	}
+}
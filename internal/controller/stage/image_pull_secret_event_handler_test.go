@@ -0,0 +1,113 @@
+package stage
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+func TestImagePullSecretEventHandler_Update(t *testing.T) {
+	scheme := runtime.NewScheme()
+	err := cdPipeApi.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	newSecret := func(name string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+			},
+		}
+	}
+
+	newStage := func(namespace, name string, secrets ...string) *cdPipeApi.Stage {
+		return &cdPipeApi.Stage{
+			ObjectMeta: metaV1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Spec: cdPipeApi.StageSpec{
+				ImagePullSecrets: secrets,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		evt     event.UpdateEvent
+		objects []client.Object
+		expLen  int
+	}{
+		{
+			name: "should add stages with the default secret to queue",
+			evt:  event.UpdateEvent{ObjectNew: newSecret("regcred")},
+			objects: []client.Object{
+				newStage("default", "dev"),
+				newStage("default", "qa"),
+				newStage("default", "prod", "quay-creds"),
+				newStage("other", "dev"),
+			},
+			expLen: 2,
+		},
+		{
+			name: "should add stages with the custom secret to queue",
+			evt:  event.UpdateEvent{ObjectNew: newSecret("quay-creds")},
+			objects: []client.Object{
+				newStage("default", "dev"),
+				newStage("default", "prod", "regcred", "quay-creds"),
+			},
+			expLen: 1,
+		},
+		{
+			name: "secret is not image pull secret",
+			evt:  event.UpdateEvent{ObjectNew: newSecret("other-secret")},
+			objects: []client.Object{
+				newStage("default", "dev"),
+			},
+			expLen: 0,
+		},
+		{
+			name:   "empty update event object",
+			evt:    event.UpdateEvent{},
+			expLen: 0,
+		},
+		{
+			name: "event object with invalid kind",
+			evt: event.UpdateEvent{
+				ObjectNew: newStage("default", "regcred"),
+			},
+			objects: []client.Object{
+				newStage("default", "dev"),
+			},
+			expLen: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewImagePullSecretEventHandler(
+				fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+				logr.Discard(),
+			)
+
+			q := &controllertest.Queue{TypedInterface: workqueue.NewTyped[reconcile.Request]()}
+
+			h.Update(t.Context(), tt.evt, q)
+
+			assert.Equal(t, tt.expLen, q.Len())
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package stage
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
+)
+
+var _ handler.EventHandler = &KrciConfigEventHandler{}
+
+// KrciConfigEventHandler is a handler for ConfigMap events,
+// which triggers reconciliation of all stages in the namespace if the platform configuration is changed.
+// It allows switching the tenant secret manager without the operator restart.
+type KrciConfigEventHandler struct {
+	client client.Client
+	log    logr.Logger
+}
+
+// NewKrciConfigEventHandler creates a new KrciConfigEventHandler.
+func NewKrciConfigEventHandler(c client.Client, log logr.Logger) *KrciConfigEventHandler {
+	return &KrciConfigEventHandler{client: c, log: log}
+}
+
+// Create triggers stages in the namespace of the platform configuration.
+func (h *KrciConfigEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	h.enqueueStages(ctx, evt.Object, q)
+}
+
+// Update triggers stages in the namespace of the platform configuration.
+func (h *KrciConfigEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	h.enqueueStages(ctx, evt.ObjectNew, q)
+}
+
+// nolint
+// Delete does nothing, skip event.
+func (h *KrciConfigEventHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+// nolint
+// Generic does nothing, skip event.
+func (h *KrciConfigEventHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *KrciConfigEventHandler) enqueueStages(
+	ctx context.Context,
+	obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	if obj == nil {
+		h.log.Info("Object is nil")
+		return
+	}
+
+	if _, ok := obj.(*corev1.ConfigMap); !ok {
+		h.log.Info("Object is not ConfigMap")
+		return
+	}
+
+	if obj.GetName() != platform.KrciConfigMap {
+		return
+	}
+
+	stages := &cdPipeApi.StageList{}
+	if err := h.client.List(
+		ctx,
+		stages,
+		client.InNamespace(obj.GetNamespace()),
+		client.Limit(clientLimit),
+	); err != nil {
+		h.log.Error(err, "unable to get stages for platform configuration", "config map", obj.GetName())
+		return
+	}
+
+	for i := range stages.Items {
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: stages.Items[i].GetNamespace(),
+			Name:      stages.Items[i].GetName(),
+		}})
+	}
+}
@@ -0,0 +1,97 @@
+package stage
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+var _ handler.EventHandler = &NamespaceProfileEventHandler{}
+
+// NamespaceProfileEventHandler is a handler for ConfigMap events,
+// which triggers reconciliation of all stages that use the ConfigMap as a namespace profile.
+type NamespaceProfileEventHandler struct {
+	client client.Client
+	log    logr.Logger
+}
+
+// NewNamespaceProfileEventHandler creates a new NamespaceProfileEventHandler.
+func NewNamespaceProfileEventHandler(c client.Client, log logr.Logger) *NamespaceProfileEventHandler {
+	return &NamespaceProfileEventHandler{client: c, log: log}
+}
+
+// Create triggers stages that use the namespace profile.
+// The profile can be created after the Stage that references it.
+func (h *NamespaceProfileEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	h.enqueueStages(ctx, evt.Object, q)
+}
+
+// Update triggers stages that use the namespace profile.
+func (h *NamespaceProfileEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	h.enqueueStages(ctx, evt.ObjectNew, q)
+}
+
+// nolint
+// Delete does nothing, skip event.
+func (h *NamespaceProfileEventHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+// nolint
+// Generic does nothing, skip event.
+func (h *NamespaceProfileEventHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *NamespaceProfileEventHandler) enqueueStages(
+	ctx context.Context,
+	obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	if obj == nil {
+		h.log.Info("Object is nil")
+		return
+	}
+
+	if _, ok := obj.(*corev1.ConfigMap); !ok {
+		h.log.Info("Object is not ConfigMap")
+		return
+	}
+
+	stages := &cdPipeApi.StageList{}
+	if err := h.client.List(
+		ctx,
+		stages,
+		client.InNamespace(obj.GetNamespace()),
+		client.Limit(clientLimit),
+	); err != nil {
+		h.log.Error(err, "unable to get stages for namespace profile", "namespace profile", obj.GetName())
+		return
+	}
+
+	for i := range stages.Items {
+		if stages.Items[i].Spec.NamespaceProfile != obj.GetName() {
+			continue
+		}
+
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: stages.Items[i].GetNamespace(),
+			Name:      stages.Items[i].GetName(),
+		}})
+	}
+}
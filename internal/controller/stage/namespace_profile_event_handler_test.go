@@ -0,0 +1,125 @@
+package stage
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+func TestNamespaceProfileEventHandler_Update(t *testing.T) {
+	scheme := runtime.NewScheme()
+	err := cdPipeApi.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	profile := &corev1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "default",
+			Name:      "restricted",
+		},
+	}
+
+	newStage := func(namespace, name, profile string) *cdPipeApi.Stage {
+		return &cdPipeApi.Stage{
+			ObjectMeta: metaV1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Spec: cdPipeApi.StageSpec{
+				NamespaceProfile: profile,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		evt     event.UpdateEvent
+		objects []client.Object
+		expLen  int
+	}{
+		{
+			name: "should add stages with the profile to queue",
+			evt:  event.UpdateEvent{ObjectNew: profile},
+			objects: []client.Object{
+				newStage("default", "dev", "restricted"),
+				newStage("default", "qa", "restricted"),
+				newStage("default", "prod", "privileged"),
+				newStage("default", "stage", ""),
+				newStage("other", "dev", "restricted"),
+			},
+			expLen: 2,
+		},
+		{
+			name:   "empty update event object",
+			evt:    event.UpdateEvent{},
+			expLen: 0,
+		},
+		{
+			name: "event object with invalid kind",
+			evt: event.UpdateEvent{
+				ObjectNew: newStage("default", "restricted", ""),
+			},
+			objects: []client.Object{
+				newStage("default", "dev", "restricted"),
+			},
+			expLen: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewNamespaceProfileEventHandler(
+				fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+				logr.Discard(),
+			)
+
+			q := &controllertest.Queue{TypedInterface: workqueue.NewTyped[reconcile.Request]()}
+
+			h.Update(t.Context(), tt.evt, q)
+
+			assert.Equal(t, tt.expLen, q.Len())
+		})
+	}
+}
+
+func TestNamespaceProfileEventHandler_Create(t *testing.T) {
+	scheme := runtime.NewScheme()
+	err := cdPipeApi.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	h := NewNamespaceProfileEventHandler(
+		fake.NewClientBuilder().WithScheme(scheme).WithObjects(&cdPipeApi.Stage{
+			ObjectMeta: metaV1.ObjectMeta{
+				Namespace: "default",
+				Name:      "dev",
+			},
+			Spec: cdPipeApi.StageSpec{
+				NamespaceProfile: "restricted",
+			},
+		}).Build(),
+		logr.Discard(),
+	)
+
+	q := &controllertest.Queue{TypedInterface: workqueue.NewTyped[reconcile.Request]()}
+
+	h.Create(t.Context(), event.CreateEvent{Object: &corev1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "default",
+			Name:      "restricted",
+		},
+	}}, q)
+
+	assert.Equal(t, 1, q.Len())
+}
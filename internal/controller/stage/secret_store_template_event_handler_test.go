@@ -0,0 +1,123 @@
+package stage
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/externalsecrets"
+)
+
+func TestSecretStoreTemplateEventHandler_Update(t *testing.T) {
+	scheme := runtime.NewScheme()
+	err := cdPipeApi.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	storeTemplate := &corev1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "default",
+			Name:      "vault",
+		},
+	}
+
+	newStage := func(namespace, name, templateName string) *cdPipeApi.Stage {
+		return &cdPipeApi.Stage{
+			ObjectMeta: metaV1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Spec: cdPipeApi.StageSpec{
+				SecretStoreTemplate: templateName,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		evt     event.UpdateEvent
+		objects []client.Object
+		expLen  int
+	}{
+		{
+			name: "should add stages with the template to queue",
+			evt:  event.UpdateEvent{ObjectNew: storeTemplate},
+			objects: []client.Object{
+				newStage("default", "dev", "vault"),
+				newStage("default", "qa", "vault"),
+				newStage("default", "prod", "aws"),
+				newStage("default", "stage", ""),
+				newStage("other", "dev", "vault"),
+			},
+			expLen: 2,
+		},
+		{
+			name:   "empty update event object",
+			evt:    event.UpdateEvent{},
+			expLen: 0,
+		},
+		{
+			name: "event object with invalid kind",
+			evt: event.UpdateEvent{
+				ObjectNew: newStage("default", "vault", ""),
+			},
+			objects: []client.Object{
+				newStage("default", "dev", "vault"),
+			},
+			expLen: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewSecretStoreTemplateEventHandler(
+				fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+				logr.Discard(),
+			)
+
+			q := &controllertest.Queue{TypedInterface: workqueue.NewTyped[reconcile.Request]()}
+
+			h.Update(t.Context(), tt.evt, q)
+
+			assert.Equal(t, tt.expLen, q.Len())
+		})
+	}
+}
+
+func TestSecretStoreTemplateEventHandler_Create(t *testing.T) {
+	scheme := runtime.NewScheme()
+	err := cdPipeApi.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	h := NewSecretStoreTemplateEventHandler(
+		fake.NewClientBuilder().WithScheme(scheme).WithObjects(&cdPipeApi.Stage{
+			ObjectMeta: metaV1.ObjectMeta{
+				Namespace: "default",
+				Name:      "dev",
+			},
+		}).Build(),
+		logr.Discard(),
+	)
+
+	q := &controllertest.Queue{TypedInterface: workqueue.NewTyped[reconcile.Request]()}
+
+	h.Create(t.Context(), event.CreateEvent{Object: &corev1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "default",
+			Name:      externalsecrets.DefaultTemplateName,
+		},
+	}}, q)
+
+	assert.Equal(t, 1, q.Len())
+}
@@ -7,7 +7,9 @@ import (
 	"reflect"
 	"time"
 
+	argoApi "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -30,6 +32,9 @@ const (
 	envLabelDeletionFinalizer   = "envLabelDeletion"
 	const15Requeue              = 15 * time.Second
 	waitForParentStagesDeletion = time.Second
+	// namespaceProfileResync is a period of the namespace profile reconciliation.
+	// Resources in the target clusters are not watched, so drift is corrected periodically.
+	namespaceProfileResync = 10 * time.Minute
 )
 
 func NewReconcileStage(
@@ -84,6 +89,11 @@ func (r *ReconcileStage) SetupWithManager(mgr ctrl.Manager) error {
 	if err := ctrl.NewControllerManagedBy(mgr).
 		For(&cdPipeApi.Stage{}, builder.WithPredicates(p)).
 		Watches(&cdPipeApi.CDPipeline{}, NewPipelineEventHandler(r.client, r.log)).
+		Watches(&corev1.ConfigMap{}, NewNamespaceProfileEventHandler(r.client, r.log)).
+		Watches(&corev1.ConfigMap{}, NewSecretStoreTemplateEventHandler(r.client, r.log)).
+		Watches(&corev1.ConfigMap{}, NewKrciConfigEventHandler(r.client, r.log)).
+		Watches(&corev1.Secret{}, NewImagePullSecretEventHandler(r.client, r.log)).
+		Watches(&argoApi.ApplicationSet{}, NewApplicationSetEventHandler(r.client, r.log)).
 		Complete(r); err != nil {
 		return fmt.Errorf("failed to create controller manager: %w", err)
 	}
@@ -94,6 +104,8 @@ func (r *ReconcileStage) SetupWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=stages,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=stages/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=stages/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=argoproj.io,namespace=placeholder,resources=applicationsets,verbs=get;list;watch;update;patch;create
 
 func (r *ReconcileStage) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -158,6 +170,10 @@ func (r *ReconcileStage) Reconcile(ctx context.Context, request reconcile.Reques
 
 	log.Info("Reconciling Stage has been finished")
 
+	if stage.Spec.NamespaceProfile != "" {
+		return reconcile.Result{RequeueAfter: namespaceProfileResync}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -194,13 +210,27 @@ func (r *ReconcileStage) tryToDeleteCDStage(ctx context.Context, stage *cdPipeAp
 
 	log.Info("Stage is last. Delete chain")
 
+	// The finalizer is kept until all the Stage resources are cleaned up according to the deletion policy.
+	// The reason is surfaced in the Stage status.
 	ch, err := chain.CreateDeleteChain(ctx, r.client, stage)
 	if err != nil {
-		return &reconcile.Result{}, fmt.Errorf("failed to create delete chain: %w", err)
+		err = fmt.Errorf("failed to create delete chain: %w", err)
+
+		if statusErr := r.setFailedStatus(ctx, stage, err); statusErr != nil {
+			log.Error(statusErr, "Failed to set failed status")
+		}
+
+		return &reconcile.Result{}, err
 	}
 
 	if err = ch.ServeRequest(ctx, stage); err != nil {
-		return &reconcile.Result{}, fmt.Errorf("failed to delete Stage: %w", err)
+		err = fmt.Errorf("failed to delete Stage: %w", err)
+
+		if statusErr := r.setFailedStatus(ctx, stage, err); statusErr != nil {
+			log.Error(statusErr, "Failed to set failed status")
+		}
+
+		return &reconcile.Result{}, err
 	}
 
 	log.Info("Removing finalizer from Stage", "finalizer", envLabelDeletionFinalizer)
@@ -221,7 +251,7 @@ func (r *ReconcileStage) setFinishStatus(ctx context.Context, s *cdPipeApi.Stage
 		Status:          consts.FinishedStatus,
 		Available:       true,
 		LastTimeUpdated: metaV1.Now(),
-		Username:        "system",
+		Username:        cdPipeApi.LastModifiedBy(s),
 		Action:          cdPipeApi.AcceptCDStageRegistration,
 		Result:          cdPipeApi.Success,
 		Value:           "active",
@@ -243,7 +273,7 @@ func (r *ReconcileStage) setFailedStatus(ctx context.Context, stage *cdPipeApi.S
 		Status:          consts.FailedStatus,
 		Available:       false,
 		LastTimeUpdated: metaV1.Now(),
-		Username:        stage.Status.Username,
+		Username:        cdPipeApi.LastModifiedBy(stage),
 		Result:          cdPipeApi.Error,
 		DetailedMessage: err.Error(),
 		Value:           consts.FailedStatus,
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	k8sApi "k8s.io/api/rbac/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -96,6 +97,7 @@ func TestTryToDeleteCDStage_Success(t *testing.T) {
 	require.NoError(t, corev1.AddToScheme(scheme))
 	require.NoError(t, projectApi.Install(scheme))
 	require.NoError(t, argoApi.AddToScheme(scheme))
+	require.NoError(t, k8sApi.AddToScheme(scheme))
 
 	stage := &cdPipeApi.Stage{
 		TypeMeta: metaV1.TypeMeta{},
@@ -173,6 +175,56 @@ func TestTryToDeleteCDStage_Success(t *testing.T) {
 	assert.True(t, k8sErrors.IsNotFound(err))
 }
 
+func TestTryToDeleteCDStage_ClusterIsNotAvailable(t *testing.T) {
+	scheme := runtime.NewScheme()
+
+	require.NoError(t, cdPipeApi.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	stage := &cdPipeApi.Stage{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			DeletionTimestamp: &metaV1.Time{
+				Time: time.Now().UTC(),
+			},
+			Finalizers: []string{envLabelDeletionFinalizer},
+		},
+		Spec: cdPipeApi.StageSpec{
+			Name:        name,
+			CdPipeline:  cdPipeline,
+			ClusterName: "unknown-cluster",
+			Namespace:   "stage-ns",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(stage).
+		WithStatusSubresource(stage).
+		Build()
+
+	reconcileStage := ReconcileStage{
+		client: fakeClient,
+		scheme: scheme,
+		log:    logr.Discard(),
+	}
+
+	_, err := reconcileStage.tryToDeleteCDStage(ctrl.LoggerInto(context.Background(), logr.Discard()), stage)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get cluster unknown-cluster")
+
+	updatedStage := &cdPipeApi.Stage{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}, updatedStage))
+
+	assert.Equal(t, []string{envLabelDeletionFinalizer}, updatedStage.Finalizers)
+	assert.Equal(t, consts.FailedStatus, updatedStage.Status.Status)
+	assert.Contains(t, updatedStage.Status.DetailedMessage, "failed to get cluster unknown-cluster")
+}
+
 func TestTryToDeleteCDStage_PostponeDeletion(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, cdPipeApi.AddToScheme(scheme))
@@ -251,6 +303,7 @@ func TestReconcileStage_Reconcile_Success(t *testing.T) {
 	require.NoError(t, corev1.AddToScheme(scheme))
 	require.NoError(t, projectApi.Install(scheme))
 	require.NoError(t, argoApi.AddToScheme(scheme))
+	require.NoError(t, k8sApi.AddToScheme(scheme))
 
 	stage := &cdPipeApi.Stage{
 		TypeMeta: metaV1.TypeMeta{},
@@ -345,6 +398,7 @@ func TestReconcileStage_ReconcileReconcile_SetOwnerRef(t *testing.T) {
 	require.NoError(t, cdPipeApi.AddToScheme(scheme))
 	require.NoError(t, codebaseApi.AddToScheme(scheme))
 	require.NoError(t, k8sApi.AddToScheme(scheme))
+	require.NoError(t, networkingv1.AddToScheme(scheme))
 	require.NoError(t, argoApi.AddToScheme(scheme))
 
 	cm := &corev1.ConfigMap{
@@ -1,16 +1,19 @@
 package argocd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 
 	"maps"
 
 	argoApi "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,6 +24,7 @@ import (
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 
 	cdPipeApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/multiclusterclient"
 )
 
 type generatorElement struct {
@@ -35,10 +39,41 @@ type generatorElement struct {
 	GitUrlPath      string `json:"gitUrlPath"`
 	VersionType     string `json:"versionType"`
 	CustomValues    bool   `json:"customValues"`
+	// AppNameSuffix is used to distinguish applications of the stage deployed to additional clusters.
+	AppNameSuffix string `json:"appNameSuffix,omitempty"`
+	// ServiceAccounts are names of the application ServiceAccounts of the stage.
+	ServiceAccounts []string `json:"serviceAccounts"`
+	// CDPipeline is the name of the CDPipeline of the stage.
+	CDPipeline string `json:"cdPipeline"`
+	// StageOrder is the order of the stage in the CDPipeline.
+	StageOrder string `json:"stageOrder"`
+	// ClusterServer is the API server URL of the cluster.
+	ClusterServer string `json:"clusterServer"`
+	// Variables are free-form variables of the stage.
+	Variables map[string]string `json:"variables"`
+}
+
+// key returns a unique key of the generator element.
+func (e *generatorElement) key() string {
+	if e.AppNameSuffix != "" {
+		return fmt.Sprintf("%s-%s-%s", e.Codebase, e.Stage, e.AppNameSuffix)
+	}
+
+	return fmt.Sprintf("%s-%s", e.Codebase, e.Stage)
 }
 
 const codebaseTypeSystem = "system"
 
+// syncedElementKeys are keys of the generator element that are kept in sync with the Stage
+// in the elements that already exist in the ApplicationSet. Other keys, e.g. imageTag, are not changed.
+var syncedElementKeys = []string{"serviceAccounts", "cdPipeline", "stageOrder", "clusterServer", "variables"}
+
+// inClusterServer is the API server URL of the cluster where ArgoCD is running.
+const inClusterServer = "https://kubernetes.default.svc"
+
+// appNameTemplateSuffix adds the cluster name to the Application name for additional stage clusters.
+const appNameTemplateSuffix = `{{ with index . "appNameSuffix" }}-{{ . }}{{ end }}`
+
 var gitOpsCodebaseLabels = map[string]string{
 	"app.edp.epam.com/codebaseType": "system",
 	"app.edp.epam.com/systemType":   "gitops",
@@ -138,6 +173,10 @@ func (c *ArgoApplicationSetManager) CreateApplicationSetGenerators(ctx context.C
 		return err
 	}
 
+	if len(stage.Spec.Clusters) > 0 && setAppNameTemplateSuffix(pipeline.Name, appset) {
+		changed = true
+	}
+
 	if changed {
 		if err = c.client.Update(ctx, appset); err != nil {
 			return fmt.Errorf("failed to update ArgoApplicationSet: %w", err)
@@ -211,13 +250,137 @@ func (c *ArgoApplicationSetManager) RemoveApplicationSetGenerators(ctx context.C
 	return nil
 }
 
+// StageImage is an image of the application deployed to the stage.
+type StageImage struct {
+	Tag    string
+	Digest string
+}
+
+// GetStageImages returns images of the applications deployed to the stage cluster by codebase name.
+// Applications that are not deployed yet are skipped.
+func (c *ArgoApplicationSetManager) GetStageImages(
+	ctx context.Context,
+	stage *cdPipeApi.Stage,
+) (map[string]StageImage, error) {
+	appset := &argoApi.ApplicationSet{}
+	if err := c.client.Get(ctx, client.ObjectKey{
+		Namespace: stage.Namespace,
+		Name:      stage.Spec.CdPipeline,
+	}, appset); err != nil {
+		if errors.IsNotFound(err) {
+			return map[string]StageImage{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to get ArgoApplicationSet: %w", err)
+	}
+
+	images := make(map[string]StageImage)
+
+	for _, gen := range appset.Spec.Generators {
+		if gen.List == nil {
+			continue
+		}
+
+		for _, rawel := range gen.List.Elements {
+			el := &generatorElement{}
+			if err := json.Unmarshal(rawel.Raw, el); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal generator element: %w", err)
+			}
+
+			if el.Stage != stage.Spec.Name || el.Cluster != stage.Spec.ClusterName {
+				continue
+			}
+
+			if el.ImageTag == "" || el.ImageTag == "NaN" {
+				continue
+			}
+
+			images[el.Codebase] = StageImage{
+				Tag:    el.ImageTag,
+				Digest: el.ImageDigest,
+			}
+		}
+	}
+
+	return images, nil
+}
+
+// ChangedStages returns names of the stages whose generator elements differ in the given ApplicationSets.
+func ChangedStages(oldAppset, newAppset *argoApi.ApplicationSet) []string {
+	oldElements := elementsByStage(oldAppset)
+	newElements := elementsByStage(newAppset)
+
+	changed := make([]string, 0)
+
+	for stage, elements := range newElements {
+		if !slices.Equal(oldElements[stage], elements) {
+			changed = append(changed, stage)
+		}
+	}
+
+	for stage := range oldElements {
+		if _, ok := newElements[stage]; !ok {
+			changed = append(changed, stage)
+		}
+	}
+
+	slices.Sort(changed)
+
+	return changed
+}
+
+// elementsByStage groups raw generator elements of the ApplicationSet by stage.
+func elementsByStage(appset *argoApi.ApplicationSet) map[string][]string {
+	elements := make(map[string][]string)
+
+	for _, gen := range appset.Spec.Generators {
+		if gen.List == nil {
+			continue
+		}
+
+		for _, rawel := range gen.List.Elements {
+			el := &generatorElement{}
+			if err := json.Unmarshal(rawel.Raw, el); err != nil {
+				continue
+			}
+
+			elements[el.Stage] = append(elements[el.Stage], string(rawel.Raw))
+		}
+	}
+
+	return elements
+}
+
 func (c *ArgoApplicationSetManager) makeStageGenerators(
 	ctx context.Context,
 	stage *cdPipeApi.Stage,
 	codebases map[string]codebaseApi.Codebase,
 	gitServers map[string]codebaseApi.GitServer,
 ) (map[string]apiextensionsv1.JSON, error) {
-	stageGenerators := make(map[string]apiextensionsv1.JSON, len(codebases))
+	targets := stage.GetClusterTargets()
+	stageGenerators := make(map[string]apiextensionsv1.JSON, len(codebases)*len(targets))
+
+	serviceAccounts := make([]string, 0, len(stage.Spec.ServiceAccounts))
+	for _, sa := range stage.Spec.ServiceAccounts {
+		serviceAccounts = append(serviceAccounts, sa.Name)
+	}
+
+	variables := make(map[string]string, len(stage.Spec.Variables))
+	for k, v := range stage.Spec.Variables {
+		variables[k] = v
+	}
+
+	servers := make(map[string]string, len(targets))
+
+	for _, target := range targets {
+		// The server URL is informational, so an unavailable cluster Secret must not block the stage generators.
+		server, err := c.getClusterServer(ctx, stage.Namespace, target.Name)
+		if err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "Failed to get cluster server", "cluster", target.Name)
+		}
+
+		servers[target.Name] = server
+	}
 
 	for k := range codebases {
 		spec := codebases[k].Spec
@@ -232,37 +395,73 @@ func (c *ArgoApplicationSetManager) makeStageGenerators(
 			return nil, fmt.Errorf("git server %s not found", spec.GitServer)
 		}
 
-		gen := generatorElement{
-			Stage:           stage.Spec.Name,
-			Codebase:        codebases[k].Name,
-			ImageTag:        "NaN",
-			ImageRepository: image,
-			Cluster:         stage.Spec.ClusterName,
-			Namespace:       stage.Spec.Namespace,
-			RepoURL: fmt.Sprintf(
-				"ssh://%s@%s:%d%s",
-				gitServer.Spec.GitUser,
-				gitServer.Spec.GitHost,
-				gitServer.Spec.SshPort,
-				spec.GitUrlPath,
-			),
-			GitUrlPath:   spec.GetProjectID(),
-			VersionType:  string(spec.Versioning.Type),
-			CustomValues: false,
-		}
+		for i, target := range targets {
+			gen := generatorElement{
+				Stage:           stage.Spec.Name,
+				Codebase:        codebases[k].Name,
+				ImageTag:        "NaN",
+				ImageRepository: image,
+				Cluster:         target.Name,
+				Namespace:       target.Namespace,
+				RepoURL: fmt.Sprintf(
+					"ssh://%s@%s:%d%s",
+					gitServer.Spec.GitUser,
+					gitServer.Spec.GitHost,
+					gitServer.Spec.SshPort,
+					spec.GitUrlPath,
+				),
+				GitUrlPath:      spec.GetProjectID(),
+				VersionType:     string(spec.Versioning.Type),
+				CustomValues:    false,
+				ServiceAccounts: serviceAccounts,
+				CDPipeline:      stage.Spec.CdPipeline,
+				StageOrder:      strconv.Itoa(stage.Spec.Order),
+				ClusterServer:   servers[target.Name],
+				Variables:       variables,
+			}
 
-		var raw []byte
+			// The first target is the main stage cluster, it keeps the Application name unchanged.
+			if i > 0 {
+				gen.AppNameSuffix = target.Name
+			}
 
-		if raw, err = json.Marshal(gen); err != nil {
-			return nil, fmt.Errorf("failed to marshal generator element: %w", err)
-		}
+			var raw []byte
+
+			if raw, err = json.Marshal(gen); err != nil {
+				return nil, fmt.Errorf("failed to marshal generator element: %w", err)
+			}
 
-		stageGenerators[fmt.Sprintf("%s-%s", codebases[k].Name, stage.Spec.Name)] = apiextensionsv1.JSON{Raw: raw}
+			stageGenerators[gen.key()] = apiextensionsv1.JSON{Raw: raw}
+		}
 	}
 
 	return stageGenerators, nil
 }
 
+// getClusterServer returns the API server URL of the cluster from its connection Secret.
+func (c *ArgoApplicationSetManager) getClusterServer(ctx context.Context, ns, clusterName string) (string, error) {
+	if clusterName == "" || clusterName == cdPipeApi.InCluster {
+		return inClusterServer, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: ns, Name: clusterName}, secret); err != nil {
+		return "", fmt.Errorf("failed to get cluster %s secret: %w", clusterName, err)
+	}
+
+	// ArgoCD cluster Secrets keep the server URL in a separate key.
+	if server := string(secret.Data["server"]); server != "" {
+		return server, nil
+	}
+
+	restConf, err := multiclusterclient.ClusterSecretToRestConfig(secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster %s server: %w", clusterName, err)
+	}
+
+	return restConf.Host, nil
+}
+
 func (c *ArgoApplicationSetManager) getImageRepo(ctx context.Context, ns, codebaseName, branch string) (string, error) {
 	image := &codebaseApi.CodebaseImageStream{}
 	if err := c.client.Get(ctx, client.ObjectKey{
@@ -413,7 +612,7 @@ func generateApplicationSet(
 			TemplatePatch:     &templatePatch,
 			Template: argoApi.ApplicationSetTemplate{
 				ApplicationSetTemplateMeta: argoApi.ApplicationSetTemplateMeta{
-					Name:       fmt.Sprintf("%s-{{ .stage }}-{{ .codebase }}", pipeline.Name),
+					Name:       appNameTemplate(pipeline.Name),
 					Finalizers: []string{"resources-finalizer.argocd.argoproj.io"}, // check if it is our or argo's responsibility
 					Labels: map[string]string{
 						"app.edp.epam.com/app-name": "{{ .codebase }}",
@@ -455,6 +654,24 @@ func generateApplicationSet(
 	}
 }
 
+// appNameTemplate returns a template of the Application name.
+func appNameTemplate(pipeline string) string {
+	return fmt.Sprintf("%s-{{ .stage }}-{{ .codebase }}%s", pipeline, appNameTemplateSuffix)
+}
+
+// setAppNameTemplateSuffix adds the cluster suffix to the Application name template
+// of ApplicationSets created before stages supported additional clusters.
+func setAppNameTemplateSuffix(pipeline string, appset *argoApi.ApplicationSet) bool {
+	legacyName := fmt.Sprintf("%s-{{ .stage }}-{{ .codebase }}", pipeline)
+	if appset.Spec.Template.Name != legacyName {
+		return false
+	}
+
+	appset.Spec.Template.Name = appNameTemplate(pipeline)
+
+	return true
+}
+
 func setGenerators(
 	stageName string,
 	appset *argoApi.ApplicationSet,
@@ -524,7 +741,7 @@ func filterAndUpdateElements(
 		var el generatorElement
 
 		_ = json.Unmarshal(rawel.Raw, &el) // error is handled in main func.
-		key := fmt.Sprintf("%s-%s", el.Codebase, el.Stage)
+		key := el.key()
 		_, exists := remaining[key]
 
 		if exists {
@@ -532,20 +749,63 @@ func filterAndUpdateElements(
 			delete(remaining, key)
 		}
 		// Keep element if it's not for this stage, or if it's for this stage and still present in stageGenerators.
-		if el.Stage != stageName || (el.Stage == stageName && exists) {
+		if el.Stage != stageName {
 			filtered = append(filtered, rawel)
+		} else if exists {
+			filtered = append(filtered, syncElement(rawel, stageGenerators[key]))
 		}
 	}
 
 	return filtered, remaining
 }
 
+// syncElement updates syncedElementKeys of the existing element with the values of the desired one.
+// The existing element is returned as is if the keys are already in sync.
+func syncElement(existing, desired apiextensionsv1.JSON) apiextensionsv1.JSON {
+	var current, wanted map[string]json.RawMessage
+
+	if json.Unmarshal(existing.Raw, &current) != nil || json.Unmarshal(desired.Raw, &wanted) != nil {
+		return existing
+	}
+
+	changed := false
+
+	for _, k := range syncedElementKeys {
+		wantedVal, wantedOk := wanted[k]
+		currentVal, currentOk := current[k]
+
+		if wantedOk == currentOk && bytes.Equal(wantedVal, currentVal) {
+			continue
+		}
+
+		if wantedOk {
+			current[k] = wantedVal
+		} else {
+			delete(current, k)
+		}
+
+		changed = true
+	}
+
+	if !changed {
+		return existing
+	}
+
+	raw, err := json.Marshal(current)
+	if err != nil {
+		return existing
+	}
+
+	return apiextensionsv1.JSON{Raw: raw}
+}
+
 // sortElementsByStageAndCodebase sorts elements by Stage and Codebase for deterministic output.
 func sortElementsByStageAndCodebase(elements []apiextensionsv1.JSON) ([]apiextensionsv1.JSON, error) {
 	type sortableElement struct {
-		Raw      []byte
-		Stage    string
-		Codebase string
+		Raw           []byte
+		Stage         string
+		Codebase      string
+		AppNameSuffix string
 	}
 
 	sorted := make([]sortableElement, 0, len(elements))
@@ -556,7 +816,12 @@ func sortElementsByStageAndCodebase(elements []apiextensionsv1.JSON) ([]apiexten
 			return nil, fmt.Errorf("failed to unmarshal generator element for sorting: %w", err)
 		}
 
-		sorted = append(sorted, sortableElement{Raw: rawel.Raw, Stage: el.Stage, Codebase: el.Codebase})
+		sorted = append(sorted, sortableElement{
+			Raw:           rawel.Raw,
+			Stage:         el.Stage,
+			Codebase:      el.Codebase,
+			AppNameSuffix: el.AppNameSuffix,
+		})
 	}
 
 	slices.SortStableFunc(sorted, func(a, b sortableElement) int {
@@ -564,7 +829,11 @@ func sortElementsByStageAndCodebase(elements []apiextensionsv1.JSON) ([]apiexten
 			return cmp
 		}
 
-		return strings.Compare(a.Codebase, b.Codebase)
+		if cmp := strings.Compare(a.Codebase, b.Codebase); cmp != 0 {
+			return cmp
+		}
+
+		return strings.Compare(a.AppNameSuffix, b.AppNameSuffix)
 	})
 
 	result := make([]apiextensionsv1.JSON, len(sorted))
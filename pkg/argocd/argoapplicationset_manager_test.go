@@ -10,6 +10,7 @@ import (
 	argoApi "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -440,6 +441,7 @@ func TestArgoApplicationSetManager_CreateApplicationSetGenerators(t *testing.T)
 	require.NoError(t, cdPipeApi.AddToScheme(scheme))
 	require.NoError(t, argoApi.AddToScheme(scheme))
 	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
 
 	tests := []struct {
 		name       string
@@ -600,8 +602,10 @@ func TestArgoApplicationSetManager_CreateApplicationSetGenerators(t *testing.T)
 					"app1": `{"cluster":"in-cluster", "codebase":"app1", "gitUrlPath":"company/app1", ` +
 						`"imageRepository":"app1-main-image", "imageDigest":"", "imageTag":"NaN", "namespace":"default", ` +
 						`"stage":"stage1", "versionType":"default", "customValues":false, ` +
-						`"repoURL": "ssh://@github.com:22/company/app1"}`,
-					"app2":   `{"stage":"stage1", "codebase": "app2"}`,
+						`"repoURL": "ssh://@github.com:22/company/app1", "cdPipeline":"pipe1", "stageOrder":"0", ` +
+						`"clusterServer":"https://kubernetes.default.svc", "serviceAccounts":[], "variables":{}}`,
+					"app2": `{"stage":"stage1", "codebase": "app2", "cdPipeline":"pipe1", "stageOrder":"0", ` +
+						`"clusterServer":"https://kubernetes.default.svc", "serviceAccounts":[], "variables":{}}`,
 					"go-app": `{"stage":"should-skip-stage", "codebase": "go-app"}`,
 				}
 
@@ -613,6 +617,127 @@ func TestArgoApplicationSetManager_CreateApplicationSetGenerators(t *testing.T)
 				}
 			},
 		},
+		{
+			name: "application set generators are created for additional stage clusters",
+			stage: &cdPipeApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipe1-stage1",
+					Namespace: ns,
+				},
+				Spec: cdPipeApi.StageSpec{
+					Name:        "stage1",
+					CdPipeline:  "pipe1",
+					Namespace:   ns,
+					ClusterName: cdPipeApi.InCluster,
+					Clusters: []cdPipeApi.ClusterTarget{
+						{Name: "eu-cluster", Namespace: "eu-ns"},
+					},
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(
+						&cdPipeApi.CDPipeline{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "pipe1",
+								Namespace: ns,
+							},
+							Spec: cdPipeApi.CDPipelineSpec{
+								Name:         "pipe1",
+								Applications: []string{"app1"},
+							},
+						},
+						&codebaseApi.Codebase{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "app1",
+								Namespace: ns,
+							},
+							Spec: codebaseApi.CodebaseSpec{
+								GitServer:     "git-server",
+								DefaultBranch: "main",
+								GitUrlPath:    "/company/app1",
+								Versioning: codebaseApi.Versioning{
+									Type: codebaseApi.VersioningTypDefault,
+								},
+							},
+						},
+						&codebaseApi.GitServer{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "git-server",
+								Namespace: ns,
+							},
+							Spec: codebaseApi.GitServerSpec{
+								GitHost: "github.com",
+								SshPort: 22,
+							},
+						},
+						&codebaseApi.CodebaseImageStream{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "app1-main",
+								Namespace: ns,
+							},
+							Spec: codebaseApi.CodebaseImageStreamSpec{
+								ImageName: "app1-main-image",
+							},
+						},
+						&corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "eu-cluster",
+								Namespace: ns,
+							},
+							Data: map[string][]byte{
+								"server": []byte("https://eu-cluster.example.com"),
+							},
+						},
+						&argoApi.ApplicationSet{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "pipe1",
+								Namespace: ns,
+							},
+							Spec: argoApi.ApplicationSetSpec{
+								Template: argoApi.ApplicationSetTemplate{
+									ApplicationSetTemplateMeta: argoApi.ApplicationSetTemplateMeta{
+										Name: "pipe1-{{ .stage }}-{{ .codebase }}",
+									},
+								},
+							},
+						},
+					).
+					Build()
+			},
+			wantErr: require.NoError,
+			wantAssert: func(t *testing.T, cl client.Client) {
+				appset := &argoApi.ApplicationSet{}
+				require.NoError(t,
+					cl.Get(context.Background(),
+						client.ObjectKey{
+							Namespace: ns,
+							Name:      "pipe1",
+						},
+						appset,
+					),
+				)
+
+				require.Equal(t, appNameTemplate("pipe1"), appset.Spec.Template.Name)
+				require.Len(t, appset.Spec.Generators, 1)
+				require.Len(t, appset.Spec.Generators[0].List.Elements, 2)
+
+				main := &generatorElement{}
+				require.NoError(t, json.Unmarshal(appset.Spec.Generators[0].List.Elements[0].Raw, main))
+				require.Equal(t, cdPipeApi.InCluster, main.Cluster)
+				require.Equal(t, ns, main.Namespace)
+				require.Empty(t, main.AppNameSuffix)
+
+				additional := &generatorElement{}
+				require.NoError(t, json.Unmarshal(appset.Spec.Generators[0].List.Elements[1].Raw, additional))
+				require.Equal(t, "eu-cluster", additional.Cluster)
+				require.Equal(t, "eu-ns", additional.Namespace)
+				require.Equal(t, "eu-cluster", additional.AppNameSuffix)
+				require.Equal(t, "https://eu-cluster.example.com", additional.ClusterServer)
+				require.Equal(t, "https://kubernetes.default.svc", main.ClusterServer)
+			},
+		},
 		{
 			name: "application set generator is created successfully with empty ApplicationSet",
 			stage: &cdPipeApi.Stage{
@@ -704,7 +829,8 @@ func TestArgoApplicationSetManager_CreateApplicationSetGenerators(t *testing.T)
 					`{"cluster":"in-cluster", "codebase":"app1", "gitUrlPath":"company/app1", `+
 						`"imageRepository":"app1-main-image", "imageDigest":"", "imageTag":"NaN", "namespace":"default", `+
 						`"stage":"stage1", "versionType":"default", "customValues":false, `+
-						`"repoURL": "ssh://@github.com:22/company/app1"}`,
+						`"repoURL": "ssh://@github.com:22/company/app1", "cdPipeline":"pipe1", "stageOrder":"0", `+
+						`"clusterServer":"https://kubernetes.default.svc", "serviceAccounts":[], "variables":{}}`,
 					string(appset.Spec.Generators[0].List.Elements[0].Raw),
 				)
 			},
@@ -787,7 +913,10 @@ func TestArgoApplicationSetManager_CreateApplicationSetGenerators(t *testing.T)
 															`"gitUrlPath":"company/app1", ` +
 															`"imageRepository":"app1-main-image", "imageTag":"NaN", ` +
 															`"namespace":"default", "stage":"stage1", ` +
-															`"versionType":"default", "customValues":false}`,
+															`"versionType":"default", "customValues":false, ` +
+															`"cdPipeline":"pipe1", "stageOrder":"0", ` +
+															`"clusterServer":"https://kubernetes.default.svc", ` +
+															`"serviceAccounts":[], "variables":{}}`,
 													),
 												},
 											},
@@ -819,7 +948,9 @@ func TestArgoApplicationSetManager_CreateApplicationSetGenerators(t *testing.T)
 					t,
 					`{"cluster":"in-cluster", "codebase":"app1", "gitUrlPath":"company/app1", `+
 						`"imageRepository":"app1-main-image", "imageTag":"NaN", "namespace":"default", `+
-						`"stage":"stage1", "versionType":"default", "customValues":false}`,
+						`"stage":"stage1", "versionType":"default", "customValues":false, `+
+						`"cdPipeline":"pipe1", "stageOrder":"0", "clusterServer":"https://kubernetes.default.svc", `+
+						`"serviceAccounts":[], "variables":{}}`,
 					string(appset.Spec.Generators[0].List.Elements[0].Raw),
 				)
 			},
@@ -1366,6 +1497,39 @@ func Test_generateTemplatePatch(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_appNameTemplate(t *testing.T) {
+	t.Parallel()
+
+	tmpl := template.Must(template.New("name").Option("missingkey=error").Parse(appNameTemplate("pipe1")))
+
+	tests := []struct {
+		name   string
+		params map[string]any
+		want   string
+	}{
+		{
+			name:   "main stage cluster",
+			params: map[string]any{"stage": "stage1", "codebase": "app1"},
+			want:   "pipe1-stage1-app1",
+		},
+		{
+			name:   "additional stage cluster",
+			params: map[string]any{"stage": "stage1", "codebase": "app1", "appNameSuffix": "eu-cluster"},
+			want:   "pipe1-stage1-app1-eu-cluster",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			require.NoError(t, tmpl.Execute(buf, tt.params))
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
 func Test_generateTemplatePatch_containsImageDigestParam(t *testing.T) {
 	patch := generateTemplatePatch("pipe1", "/company/edp-gitops")
 
@@ -1486,7 +1650,7 @@ func Test_newGeneratorElement_hasEmptyImageDigest(t *testing.T) {
 }
 
 func Test_filterAndUpdateElements_preservesOldElementsWithoutImageDigest(t *testing.T) {
-	oldJSON := []byte(`{"stage":"stage1","codebase":"app1","imageTag":"0.1.0","imageRepository":"registry/app1","cluster":"in-cluster","namespace":"default","repoURL":"ssh://git@github.com:22/company/app1","gitUrlPath":"company/app1","versionType":"default","customValues":false}`)
+	oldJSON := []byte(`{"stage":"stage1","codebase":"app1","imageTag":"0.1.0","imageRepository":"registry/app1","cluster":"in-cluster","namespace":"default","repoURL":"ssh://git@github.com:22/company/app1","gitUrlPath":"company/app1","versionType":"default","customValues":false,"serviceAccounts":[],"cdPipeline":"","stageOrder":"","clusterServer":"","variables":{}}`)
 
 	elements := []v1.JSON{
 		{Raw: oldJSON},
@@ -1504,6 +1668,8 @@ func Test_filterAndUpdateElements_preservesOldElementsWithoutImageDigest(t *test
 		GitUrlPath:      "company/app1",
 		VersionType:     "default",
 		CustomValues:    false,
+		ServiceAccounts: []string{},
+		Variables:       map[string]string{},
 	}
 	newRaw, err := json.Marshal(newGen)
 	require.NoError(t, err)
@@ -1547,7 +1713,7 @@ func Test_sortElementsByStageAndCodebase_mixedElements(t *testing.T) {
 
 func Test_processGeneratorListElements_mixedOldAndNewGenerators(t *testing.T) {
 	oldElement := v1.JSON{
-		Raw: []byte(`{"stage":"stage1","codebase":"app1","imageTag":"0.1.0","imageRepository":"registry/app1","cluster":"in-cluster","namespace":"default","repoURL":"ssh://git@github.com:22/company/app1","gitUrlPath":"company/app1","versionType":"default","customValues":false}`),
+		Raw: []byte(`{"stage":"stage1","codebase":"app1","imageTag":"0.1.0","imageRepository":"registry/app1","cluster":"in-cluster","namespace":"default","repoURL":"ssh://git@github.com:22/company/app1","gitUrlPath":"company/app1","versionType":"default","customValues":false,"serviceAccounts":[],"cdPipeline":"","stageOrder":"","clusterServer":"","variables":{}}`),
 	}
 	otherStageElement := v1.JSON{
 		Raw: []byte(`{"stage":"stage2","codebase":"app1","imageTag":"0.2.0","imageRepository":"registry/app1","cluster":"in-cluster","namespace":"ns2","repoURL":"ssh://git@github.com:22/company/app1","gitUrlPath":"company/app1","versionType":"default","customValues":false}`),
@@ -1571,6 +1737,8 @@ func Test_processGeneratorListElements_mixedOldAndNewGenerators(t *testing.T) {
 		GitUrlPath:      "company/app1",
 		VersionType:     "default",
 		CustomValues:    false,
+		ServiceAccounts: []string{},
+		Variables:       map[string]string{},
 	}
 	newRaw, err := json.Marshal(newGen)
 	require.NoError(t, err)
@@ -1597,3 +1765,182 @@ func Test_processGeneratorListElements_mixedOldAndNewGenerators(t *testing.T) {
 
 	require.True(t, foundStage2, "stage2 element must be preserved")
 }
+
+func Test_filterAndUpdateElements_syncsServiceAccounts(t *testing.T) {
+	oldJSON := []byte(`{"stage":"stage1","codebase":"app1","imageTag":"0.1.0","imageRepository":"registry/app1","cluster":"in-cluster","namespace":"default","repoURL":"ssh://git@github.com:22/company/app1","gitUrlPath":"company/app1","versionType":"default","customValues":false,"serviceAccounts":["app"]}`)
+
+	newGen := generatorElement{
+		Stage:           "stage1",
+		Codebase:        "app1",
+		ImageTag:        "NaN",
+		ImageRepository: "registry/app1",
+		Cluster:         "in-cluster",
+		Namespace:       "default",
+		RepoURL:         "ssh://git@github.com:22/company/app1",
+		GitUrlPath:      "company/app1",
+		VersionType:     "default",
+		ServiceAccounts: []string{"app", "worker"},
+	}
+	newRaw, err := json.Marshal(newGen)
+	require.NoError(t, err)
+
+	filtered, remaining := filterAndUpdateElements(
+		"stage1",
+		[]v1.JSON{{Raw: oldJSON}},
+		map[string]v1.JSON{"app1-stage1": {Raw: newRaw}},
+	)
+
+	require.Len(t, filtered, 1)
+	require.Empty(t, remaining)
+
+	var el generatorElement
+	require.NoError(t, json.Unmarshal(filtered[0].Raw, &el))
+	require.Equal(t, []string{"app", "worker"}, el.ServiceAccounts)
+	require.Equal(t, "0.1.0", el.ImageTag, "image tag of the existing element should be kept")
+
+	newGen.ServiceAccounts = []string{}
+	newRaw, err = json.Marshal(newGen)
+	require.NoError(t, err)
+
+	filtered, _ = filterAndUpdateElements(
+		"stage1",
+		[]v1.JSON{{Raw: oldJSON}},
+		map[string]v1.JSON{"app1-stage1": {Raw: newRaw}},
+	)
+
+	require.Len(t, filtered, 1)
+	require.Contains(t, string(filtered[0].Raw), `"serviceAccounts":[]`)
+	require.Contains(t, string(filtered[0].Raw), `"imageTag":"0.1.0"`)
+}
+
+func TestArgoApplicationSetManager_getClusterServer(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	kubeconfig := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: kubeconfig-cluster
+  cluster:
+    server: https://kubeconfig-cluster.example.com
+contexts:
+- name: default
+  context:
+    cluster: kubeconfig-cluster
+    user: default
+current-context: default
+users:
+- name: default
+  user:
+    token: token
+`)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "argocd-cluster", Namespace: ns},
+			Data:       map[string][]byte{"server": []byte("https://argocd-cluster.example.com")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubeconfig-cluster", Namespace: ns},
+			Data:       map[string][]byte{"config": kubeconfig},
+		},
+	).Build()
+
+	tests := []struct {
+		name        string
+		clusterName string
+		want        string
+		wantErr     require.ErrorAssertionFunc
+	}{
+		{
+			name:        "in-cluster",
+			clusterName: cdPipeApi.InCluster,
+			want:        "https://kubernetes.default.svc",
+			wantErr:     require.NoError,
+		},
+		{
+			name:        "server from ArgoCD cluster secret",
+			clusterName: "argocd-cluster",
+			want:        "https://argocd-cluster.example.com",
+			wantErr:     require.NoError,
+		},
+		{
+			name:        "server from kubeconfig",
+			clusterName: "kubeconfig-cluster",
+			want:        "https://kubeconfig-cluster.example.com",
+			wantErr:     require.NoError,
+		},
+		{
+			name:        "cluster secret not found",
+			clusterName: "unknown",
+			wantErr:     require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewArgoApplicationSetManager(cl).getClusterServer(context.Background(), ns, tt.clusterName)
+
+			tt.wantErr(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestArgoApplicationSetManager_makeStageGenerators_stageWithoutVariables(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&codebaseApi.CodebaseImageStream{
+			ObjectMeta: metav1.ObjectMeta{Name: "app1-main", Namespace: ns},
+			Spec:       codebaseApi.CodebaseImageStreamSpec{ImageName: "app1-main-image"},
+		},
+	).Build()
+
+	stage := &cdPipeApi.Stage{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipe1-stage1", Namespace: ns},
+		Spec: cdPipeApi.StageSpec{
+			Name:        "stage1",
+			CdPipeline:  "pipe1",
+			ClusterName: "unknown-cluster",
+			Namespace:   "default",
+		},
+	}
+	codebases := map[string]codebaseApi.Codebase{
+		"app1": {
+			ObjectMeta: metav1.ObjectMeta{Name: "app1", Namespace: ns},
+			Spec:       codebaseApi.CodebaseSpec{DefaultBranch: "main", GitServer: "git-server"},
+		},
+	}
+	gitServers := map[string]codebaseApi.GitServer{
+		"git-server": {Spec: codebaseApi.GitServerSpec{GitHost: "github.com", SshPort: 22}},
+	}
+
+	generators, err := NewArgoApplicationSetManager(cl).makeStageGenerators(
+		context.Background(),
+		stage,
+		codebases,
+		gitServers,
+	)
+	require.NoError(t, err, "missing cluster secret should not fail the generators")
+	require.Contains(t, generators, "app1-stage1")
+
+	params := map[string]any{}
+	require.NoError(t, json.Unmarshal(generators["app1-stage1"].Raw, &params))
+
+	tmpl := template.Must(template.New("test").Option("missingkey=error").Parse(
+		`{{ .cdPipeline }}/{{ .stageOrder }}/{{ .clusterServer }}/{{ len .serviceAccounts }}/{{ len .variables }}`,
+	))
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, tmpl.Execute(buf, params))
+	require.Equal(t, "pipe1/0//0/0", buf.String())
+}
@@ -3,6 +3,10 @@ package argocd
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -16,6 +20,25 @@ import (
 const (
 	ClusterLabel    = "argocd.argoproj.io/secret-type"
 	ClusterLabelVal = "cluster"
+
+	// ClusterNamespacesAnnotation contains a comma-separated list of namespaces
+	// that ArgoCD is allowed to manage in the cluster.
+	ClusterNamespacesAnnotation = "app.edp.epam.com/argocd-cluster-namespaces"
+	// ClusterProjectAnnotation contains the name of ArgoCD project the cluster is scoped to.
+	ClusterProjectAnnotation = "app.edp.epam.com/argocd-cluster-project"
+	// ClusterResourcesAnnotation indicates whether ArgoCD can manage cluster-scoped resources
+	// when the cluster is restricted to namespaces.
+	ClusterResourcesAnnotation = "app.edp.epam.com/argocd-cluster-resources"
+	// ClusterLabelsAnnotation contains a JSON map of labels that should be added to the ArgoCD cluster secret.
+	ClusterLabelsAnnotation = "app.edp.epam.com/argocd-cluster-labels"
+	// ClusterAnnotationsAnnotation contains a JSON map of annotations that should be added to the ArgoCD cluster secret.
+	ClusterAnnotationsAnnotation = "app.edp.epam.com/argocd-cluster-annotations"
+	// ClusterManagedLabelsAnnotation contains a comma-separated list of label keys
+	// that are set to the ArgoCD cluster secret by the operator.
+	ClusterManagedLabelsAnnotation = "app.edp.epam.com/argocd-cluster-managed-labels"
+	// ClusterManagedAnnotationsAnnotation contains a comma-separated list of annotation keys
+	// that are set to the ArgoCD cluster secret by the operator.
+	ClusterManagedAnnotationsAnnotation = "app.edp.epam.com/argocd-cluster-managed-annotations"
 )
 
 // ClusterConfig is the ArgoCD cluster configuration.
@@ -61,6 +84,142 @@ func AddClusterLabel(argoClusterSecret *corev1.Secret) {
 	argoClusterSecret.SetLabels(labels)
 }
 
+// ClusterScope defines restrictions and metadata of the ArgoCD cluster secret.
+// See https://argo-cd.readthedocs.io/en/stable/operator-manual/declarative-setup/#clusters
+type ClusterScope struct {
+	// Namespaces is a list of namespaces ArgoCD is allowed to manage in the cluster.
+	Namespaces []string
+
+	// Project is the name of ArgoCD project the cluster is scoped to.
+	Project string
+
+	// ClusterResources indicates whether ArgoCD can manage cluster-scoped resources.
+	// It is taken into account only if Namespaces are set.
+	ClusterResources bool
+
+	// Labels are added to the ArgoCD cluster secret, e.g. to select clusters by ApplicationSet cluster generator.
+	Labels map[string]string
+
+	// Annotations are added to the ArgoCD cluster secret.
+	Annotations map[string]string
+}
+
+// ClusterScopeFromSecret reads ClusterScope from the cluster secret annotations.
+func ClusterScopeFromSecret(secret *corev1.Secret) (*ClusterScope, error) {
+	annotations := secret.GetAnnotations()
+	scope := &ClusterScope{
+		Project: strings.TrimSpace(annotations[ClusterProjectAnnotation]),
+	}
+
+	for _, ns := range strings.Split(annotations[ClusterNamespacesAnnotation], ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			scope.Namespaces = append(scope.Namespaces, ns)
+		}
+	}
+
+	if val, ok := annotations[ClusterResourcesAnnotation]; ok {
+		clusterResources, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s annotation: %w", ClusterResourcesAnnotation, err)
+		}
+
+		scope.ClusterResources = clusterResources
+	}
+
+	if val, ok := annotations[ClusterLabelsAnnotation]; ok {
+		if err := json.Unmarshal([]byte(val), &scope.Labels); err != nil {
+			return nil, fmt.Errorf("failed to parse %s annotation: %w", ClusterLabelsAnnotation, err)
+		}
+	}
+
+	if val, ok := annotations[ClusterAnnotationsAnnotation]; ok {
+		if err := json.Unmarshal([]byte(val), &scope.Annotations); err != nil {
+			return nil, fmt.Errorf("failed to parse %s annotation: %w", ClusterAnnotationsAnnotation, err)
+		}
+	}
+
+	return scope, nil
+}
+
+// ApplyClusterScope sets ClusterScope to the ArgoCD cluster secret.
+// Only labels and annotations from the scope are managed by the operator,
+// metadata added by ArgoCD or users is kept. Keys that are removed from the scope are removed from the secret.
+func ApplyClusterScope(argoClusterSecret *corev1.Secret, scope *ClusterScope) {
+	if argoClusterSecret.Data == nil {
+		argoClusterSecret.Data = make(map[string][]byte, 3)
+	}
+
+	if len(scope.Namespaces) > 0 {
+		argoClusterSecret.Data["namespaces"] = []byte(strings.Join(scope.Namespaces, ","))
+		argoClusterSecret.Data["clusterResources"] = []byte(strconv.FormatBool(scope.ClusterResources))
+	}
+
+	if scope.Project != "" {
+		argoClusterSecret.Data["project"] = []byte(scope.Project)
+	}
+
+	annotations := argoClusterSecret.GetAnnotations()
+
+	labels := applyManagedKeys(
+		argoClusterSecret.GetLabels(),
+		scope.Labels,
+		splitKeys(annotations[ClusterManagedLabelsAnnotation]),
+	)
+	annotations = applyManagedKeys(
+		annotations,
+		scope.Annotations,
+		splitKeys(annotations[ClusterManagedAnnotationsAnnotation]),
+	)
+
+	setManagedKeys(annotations, ClusterManagedLabelsAnnotation, scope.Labels)
+	setManagedKeys(annotations, ClusterManagedAnnotationsAnnotation, scope.Annotations)
+
+	argoClusterSecret.SetLabels(labels)
+	argoClusterSecret.SetAnnotations(annotations)
+	AddClusterLabel(argoClusterSecret)
+}
+
+// applyManagedKeys removes previously managed keys that are not desired anymore and sets the desired ones.
+func applyManagedKeys(current, desired map[string]string, managed []string) map[string]string {
+	result := maps.Clone(current)
+	if result == nil {
+		result = make(map[string]string, len(desired))
+	}
+
+	for _, k := range managed {
+		if _, ok := desired[k]; !ok {
+			delete(result, k)
+		}
+	}
+
+	maps.Copy(result, desired)
+
+	return result
+}
+
+// setManagedKeys stores the sorted keys of the managed metadata in the annotation.
+func setManagedKeys(annotations map[string]string, annotation string, managed map[string]string) {
+	if len(managed) == 0 {
+		delete(annotations, annotation)
+
+		return
+	}
+
+	annotations[annotation] = strings.Join(slices.Sorted(maps.Keys(managed)), ",")
+}
+
+func splitKeys(val string) []string {
+	keys := make([]string, 0)
+
+	for _, k := range strings.Split(val, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
 // SecretToIRSACluster converts the ArgoCD IRSA cluster secret config to IrsaClusterConfig.
 // Secret is in format: https://argo-cd.readthedocs.io/en/stable/operator-manual/declarative-setup/#eks
 func SecretToIRSACluster(secret *corev1.Secret) (*IrsaClusterConfig, error) {
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/aws"
@@ -91,3 +92,124 @@ func TestArgoIRSAClusterSecretToKubeconfig(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterScopeFromSecret(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *ClusterScope
+		wantErr     require.ErrorAssertionFunc
+	}{
+		{
+			name: "full scope",
+			annotations: map[string]string{
+				ClusterNamespacesAnnotation:  "ns1, ns2,,",
+				ClusterProjectAnnotation:     "team-a",
+				ClusterResourcesAnnotation:   "true",
+				ClusterLabelsAnnotation:      `{"region":"eu-central-1"}`,
+				ClusterAnnotationsAnnotation: `{"owner":"team-a"}`,
+			},
+			want: &ClusterScope{
+				Namespaces:       []string{"ns1", "ns2"},
+				Project:          "team-a",
+				ClusterResources: true,
+				Labels:           map[string]string{"region": "eu-central-1"},
+				Annotations:      map[string]string{"owner": "team-a"},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:        "empty scope",
+			annotations: nil,
+			want:        &ClusterScope{},
+			wantErr:     require.NoError,
+		},
+		{
+			name: "invalid cluster resources",
+			annotations: map[string]string{
+				ClusterResourcesAnnotation: "yes-please",
+			},
+			wantErr: require.Error,
+		},
+		{
+			name: "invalid labels",
+			annotations: map[string]string{
+				ClusterLabelsAnnotation: "region=eu",
+			},
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ClusterScopeFromSecret(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			})
+
+			tt.wantErr(t, err)
+
+			if err == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestApplyClusterScope(t *testing.T) {
+	t.Parallel()
+
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"stale":   "label",
+				"foreign": "label",
+			},
+			Annotations: map[string]string{
+				"stale":                             "annotation",
+				"foreign":                           "annotation",
+				ClusterManagedLabelsAnnotation:      "stale",
+				ClusterManagedAnnotationsAnnotation: "stale",
+			},
+		},
+		Data: map[string][]byte{
+			"server": []byte("https://test-cluster"),
+		},
+	}
+
+	ApplyClusterScope(s, &ClusterScope{
+		Namespaces:  []string{"ns1", "ns2"},
+		Project:     "team-a",
+		Labels:      map[string]string{"region": "eu-central-1"},
+		Annotations: map[string]string{"owner": "team-a"},
+	})
+
+	assert.Equal(t, "https://test-cluster", string(s.Data["server"]))
+	assert.Equal(t, "ns1,ns2", string(s.Data["namespaces"]))
+	assert.Equal(t, "false", string(s.Data["clusterResources"]))
+	assert.Equal(t, "team-a", string(s.Data["project"]))
+	assert.Equal(t, map[string]string{
+		"region":     "eu-central-1",
+		"foreign":    "label",
+		ClusterLabel: ClusterLabelVal,
+	}, s.GetLabels())
+	assert.Equal(t, map[string]string{
+		"owner":                             "team-a",
+		"foreign":                           "annotation",
+		ClusterManagedLabelsAnnotation:      "region",
+		ClusterManagedAnnotationsAnnotation: "owner",
+	}, s.GetAnnotations())
+
+	ApplyClusterScope(s, &ClusterScope{})
+
+	assert.Equal(t, map[string]string{
+		"foreign":    "label",
+		ClusterLabel: ClusterLabelVal,
+	}, s.GetLabels())
+	assert.Equal(t, map[string]string{"foreign": "annotation"}, s.GetAnnotations())
+}
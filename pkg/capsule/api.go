@@ -0,0 +1,49 @@
+package capsule
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	TenantKind = "Tenant"
+	ApiVersion = "capsule.clastix.io/v1beta2"
+
+	// TenantLabel is a namespace label Capsule uses to assign the namespace to the Tenant.
+	TenantLabel = "capsule.clastix.io/tenant"
+)
+
+func NewTenant(name string) *unstructured.Unstructured {
+	tenant := &unstructured.Unstructured{}
+	tenant.Object = map[string]interface{}{
+		"kind":       TenantKind,
+		"apiVersion": ApiVersion,
+		"metadata": map[string]interface{}{
+			"name": name,
+		},
+	}
+
+	return tenant
+}
+
+// NamespaceQuota returns the maximum number of namespaces in the Tenant.
+// The second value is false if the Tenant doesn't limit the number of namespaces.
+func NamespaceQuota(tenant *unstructured.Unstructured) (int64, bool) {
+	quota, found, err := unstructured.NestedInt64(tenant.Object, "spec", "namespaceOptions", "quota")
+	if err != nil || !found {
+		return 0, false
+	}
+
+	return quota, true
+}
+
+// NamespaceCount returns the number of namespaces that belong to the Tenant.
+func NamespaceCount(tenant *unstructured.Unstructured) int64 {
+	size, found, err := unstructured.NestedInt64(tenant.Object, "status", "size")
+	if err == nil && found {
+		return size
+	}
+
+	namespaces, _, _ := unstructured.NestedStringSlice(tenant.Object, "status", "namespaces")
+
+	return int64(len(namespaces))
+}
@@ -0,0 +1,111 @@
+package externalsecrets
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// SecretStoreKey is a ConfigMap key with the SecretStore spec template in YAML format.
+	SecretStoreKey = "secretStore"
+
+	// ExternalSecretsKey is a ConfigMap key with the list of ExternalSecrets templates in YAML format.
+	ExternalSecretsKey = "externalSecrets"
+
+	// DefaultTemplateName is a name of the tenant ConfigMap with the default SecretStore template.
+	DefaultTemplateName = "secret-store-template"
+)
+
+// TemplateData contains the data available in the SecretStore template.
+type TemplateData struct {
+	// Tenant is the namespace where the Stage is created.
+	Tenant string
+	// Name is the name of the Stage resource.
+	Name string
+	// CDPipeline is the name of the CDPipeline.
+	CDPipeline string
+	// Stage is the name of the stage from spec.
+	Stage string
+	// Namespace is the Stage target namespace.
+	Namespace string
+	// Cluster is the name of the cluster where the application will be deployed.
+	Cluster string
+}
+
+// ExternalSecretTemplate is an ExternalSecret declared in the SecretStore template.
+type ExternalSecretTemplate struct {
+	// Name of the ExternalSecret and the Secret it creates.
+	Name string `json:"name"`
+
+	// Spec of the ExternalSecret. The SecretStore reference is set by the operator if it is empty.
+	Spec map[string]interface{} `json:"spec"`
+}
+
+// Template is a rendered SecretStore template.
+type Template struct {
+	// Name of the template.
+	Name string
+
+	// SecretStoreSpec is a spec of the SecretStore.
+	SecretStoreSpec map[string]interface{}
+
+	// ExternalSecrets that are created in the Stage namespace.
+	// If empty, the ExternalSecrets for the image pull secrets are created.
+	ExternalSecrets []ExternalSecretTemplate
+}
+
+// RenderTemplate renders the SecretStore template from the ConfigMap with the given data.
+// Values of the ConfigMap are Go templates, so ExternalSecret templates should be escaped,
+// e.g. {{ "{{ .secretValue }}" }}.
+func RenderTemplate(cm *corev1.ConfigMap, data TemplateData) (*Template, error) {
+	if _, ok := cm.Data[SecretStoreKey]; !ok {
+		return nil, fmt.Errorf("SecretStore template %s doesn't contain %s key", cm.Name, SecretStoreKey)
+	}
+
+	tmpl := &Template{
+		Name: cm.Name,
+	}
+
+	if err := renderKey(cm, SecretStoreKey, data, &tmpl.SecretStoreSpec); err != nil {
+		return nil, err
+	}
+
+	if err := renderKey(cm, ExternalSecretsKey, data, &tmpl.ExternalSecrets); err != nil {
+		return nil, err
+	}
+
+	for _, es := range tmpl.ExternalSecrets {
+		if es.Name == "" {
+			return nil, fmt.Errorf("ExternalSecret name is empty in SecretStore template %s", cm.Name)
+		}
+	}
+
+	return tmpl, nil
+}
+
+func renderKey(cm *corev1.ConfigMap, key string, data TemplateData, out any) error {
+	val, ok := cm.Data[key]
+	if !ok {
+		return nil
+	}
+
+	t, err := template.New(key).Option("missingkey=error").Parse(val)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s of SecretStore template %s: %w", key, cm.Name, err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err = t.Execute(buf, data); err != nil {
+		return fmt.Errorf("failed to render %s of SecretStore template %s: %w", key, cm.Name, err)
+	}
+
+	if err = yaml.Unmarshal(buf.Bytes(), out); err != nil {
+		return fmt.Errorf("failed to unmarshal %s of SecretStore template %s: %w", key, cm.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package externalsecrets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	t.Parallel()
+
+	newConfigMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vault",
+				Namespace: "default",
+			},
+			Data: data,
+		}
+	}
+
+	data := TemplateData{
+		Tenant:     "default",
+		Name:       "mypipeline-prod",
+		CDPipeline: "mypipeline",
+		Stage:      "prod",
+		Namespace:  "default-mypipeline-prod",
+		Cluster:    "in-cluster",
+	}
+
+	tests := []struct {
+		name    string
+		cm      *corev1.ConfigMap
+		want    *Template
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "vault provider with external secrets",
+			cm: newConfigMap(map[string]string{
+				SecretStoreKey: `
+provider:
+  vault:
+    server: https://vault.example.com
+    path: "{{ .Tenant }}"
+    auth:
+      kubernetes:
+        role: "{{ .CDPipeline }}-{{ .Stage }}"
+`,
+				ExternalSecretsKey: `
+- name: regcred
+  spec:
+    data:
+    - secretKey: secretValue
+      remoteRef:
+        key: "{{ .Cluster }}/regcred"
+    target:
+      template:
+        data:
+          .dockerconfigjson: '{{ "{{ .secretValue }}" }}'
+`,
+			}),
+			want: &Template{
+				Name: "vault",
+				SecretStoreSpec: map[string]interface{}{
+					"provider": map[string]interface{}{
+						"vault": map[string]interface{}{
+							"server": "https://vault.example.com",
+							"path":   "default",
+							"auth": map[string]interface{}{
+								"kubernetes": map[string]interface{}{
+									"role": "mypipeline-prod",
+								},
+							},
+						},
+					},
+				},
+				ExternalSecrets: []ExternalSecretTemplate{
+					{
+						Name: "regcred",
+						Spec: map[string]interface{}{
+							"data": []interface{}{
+								map[string]interface{}{
+									"secretKey": "secretValue",
+									"remoteRef": map[string]interface{}{
+										"key": "in-cluster/regcred",
+									},
+								},
+							},
+							"target": map[string]interface{}{
+								"template": map[string]interface{}{
+									"data": map[string]interface{}{
+										".dockerconfigjson": "{{ .secretValue }}",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "external secrets are not declared",
+			cm: newConfigMap(map[string]string{
+				SecretStoreKey: "provider:\n  aws:\n    region: \"eu-central-1\"\n",
+			}),
+			want: &Template{
+				Name: "vault",
+				SecretStoreSpec: map[string]interface{}{
+					"provider": map[string]interface{}{
+						"aws": map[string]interface{}{
+							"region": "eu-central-1",
+						},
+					},
+				},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "secret store is not declared",
+			cm:      newConfigMap(map[string]string{}),
+			want:    nil,
+			wantErr: require.Error,
+		},
+		{
+			name: "unknown template variable",
+			cm: newConfigMap(map[string]string{
+				SecretStoreKey: "provider:\n  vault:\n    path: \"{{ .Unknown }}\"\n",
+			}),
+			want: nil,
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to render secretStore of SecretStore template vault")
+			},
+		},
+		{
+			name: "external secret without name",
+			cm: newConfigMap(map[string]string{
+				SecretStoreKey:     "provider: {}\n",
+				ExternalSecretsKey: "- spec: {}\n",
+			}),
+			want: nil,
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "ExternalSecret name is empty")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := RenderTemplate(tt.cm, data)
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
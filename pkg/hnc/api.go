@@ -0,0 +1,133 @@
+// Package hnc contains a minimal subset of the Hierarchical Namespace Controller API
+// that is required to manage Stage subnamespaces.
+package hnc
+
+import (
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+const (
+	// SubnamespaceAnchorKind is a kind of the HNC SubnamespaceAnchor.
+	SubnamespaceAnchorKind = "SubnamespaceAnchor"
+
+	// AnchorOk is a status of the SubnamespaceAnchor when the subnamespace is created.
+	AnchorOk = "Ok"
+)
+
+var (
+	// GroupVersion is group version of the HNC API.
+	GroupVersion = schema.GroupVersion{Group: "hnc.x-k8s.io", Version: "v1alpha2"}
+
+	// SchemeBuilder is used to add HNC types to the GroupVersionKind scheme.
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func init() {
+	SchemeBuilder.Register(&SubnamespaceAnchor{}, &SubnamespaceAnchorList{})
+}
+
+// MetaKVP is a key-value pair of the label or annotation propagated to the subnamespace.
+type MetaKVP struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// SubnamespaceAnchorSpec defines the labels and annotations of the subnamespace.
+type SubnamespaceAnchorSpec struct {
+	Labels      []MetaKVP `json:"labels,omitempty"`
+	Annotations []MetaKVP `json:"annotations,omitempty"`
+}
+
+// SubnamespaceAnchorStatus defines the state of the subnamespace.
+type SubnamespaceAnchorStatus struct {
+	// Status is Ok if the subnamespace is created.
+	// Other values are Missing, Conflict and Forbidden.
+	Status string `json:"status,omitempty"`
+}
+
+// SubnamespaceAnchor is an object in the parent namespace that creates the subnamespace with the same name.
+type SubnamespaceAnchor struct {
+	metaV1.TypeMeta   `json:",inline"`
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubnamespaceAnchorSpec   `json:"spec,omitempty"`
+	Status SubnamespaceAnchorStatus `json:"status,omitempty"`
+}
+
+// SubnamespaceAnchorList contains a list of SubnamespaceAnchor.
+type SubnamespaceAnchorList struct {
+	metaV1.TypeMeta `json:",inline"`
+	metaV1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SubnamespaceAnchor `json:"items"`
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *SubnamespaceAnchor) DeepCopyInto(out *SubnamespaceAnchor) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+
+	if in.Spec.Labels != nil {
+		out.Spec.Labels = make([]MetaKVP, len(in.Spec.Labels))
+		copy(out.Spec.Labels, in.Spec.Labels)
+	}
+
+	if in.Spec.Annotations != nil {
+		out.Spec.Annotations = make([]MetaKVP, len(in.Spec.Annotations))
+		copy(out.Spec.Annotations, in.Spec.Annotations)
+	}
+}
+
+// DeepCopy creates a new SubnamespaceAnchor.
+func (in *SubnamespaceAnchor) DeepCopy() *SubnamespaceAnchor {
+	if in == nil {
+		return nil
+	}
+
+	out := new(SubnamespaceAnchor)
+	in.DeepCopyInto(out)
+
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *SubnamespaceAnchor) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *SubnamespaceAnchorList) DeepCopyInto(out *SubnamespaceAnchorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+
+	if in.Items != nil {
+		out.Items = make([]SubnamespaceAnchor, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy creates a new SubnamespaceAnchorList.
+func (in *SubnamespaceAnchorList) DeepCopy() *SubnamespaceAnchorList {
+	if in == nil {
+		return nil
+	}
+
+	out := new(SubnamespaceAnchorList)
+	in.DeepCopyInto(out)
+
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *SubnamespaceAnchorList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
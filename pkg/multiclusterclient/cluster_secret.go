@@ -0,0 +1,36 @@
+package multiclusterclient
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// nolint:gosec // Cluster secret label.
+	ClusterSecretTypeLabel = "app.edp.epam.com/secret-type"
+	// ClusterSecretTypeCluster is a value of ClusterSecretTypeLabel for cluster secrets.
+	ClusterSecretTypeCluster = "cluster"
+
+	// nolint:gosec // Cluster secret annotation.
+	ClusterSecretConnectionAnnotation = "app.edp.epam.com/cluster-connected"
+	// nolint:gosec // Cluster secret annotation.
+	ClusterSecretErrorAnnotation = "app.edp.epam.com/cluster-error"
+)
+
+// CheckClusterSecret checks that the secret is a cluster secret and the cluster is connected.
+func CheckClusterSecret(secret *corev1.Secret) error {
+	if secret.GetLabels()[ClusterSecretTypeLabel] != ClusterSecretTypeCluster {
+		return fmt.Errorf("secret %s doesn't have label %s=%s", secret.Name, ClusterSecretTypeLabel, ClusterSecretTypeCluster)
+	}
+
+	if secret.GetAnnotations()[ClusterSecretConnectionAnnotation] != "true" {
+		if connErr := secret.GetAnnotations()[ClusterSecretErrorAnnotation]; connErr != "" {
+			return fmt.Errorf("cluster %s is not connected: %s", secret.Name, connErr)
+		}
+
+		return fmt.Errorf("cluster %s is not connected", secret.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package multiclusterclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckClusterSecret(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		wantErr     require.ErrorAssertionFunc
+	}{
+		{
+			name:        "connected cluster secret",
+			labels:      map[string]string{ClusterSecretTypeLabel: ClusterSecretTypeCluster},
+			annotations: map[string]string{ClusterSecretConnectionAnnotation: "true"},
+			wantErr:     require.NoError,
+		},
+		{
+			name:        "secret without cluster label",
+			annotations: map[string]string{ClusterSecretConnectionAnnotation: "true"},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "doesn't have label")
+			},
+		},
+		{
+			name:   "cluster is not connected",
+			labels: map[string]string{ClusterSecretTypeLabel: ClusterSecretTypeCluster},
+			annotations: map[string]string{
+				ClusterSecretConnectionAnnotation: "false",
+				ClusterSecretErrorAnnotation:      "connection refused",
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cluster prod is not connected: connection refused")
+			},
+		},
+		{
+			name:   "cluster connection is not checked yet",
+			labels: map[string]string{ClusterSecretTypeLabel: ClusterSecretTypeCluster},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cluster prod is not connected")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.wantErr(t, CheckClusterSecret(&corev1.Secret{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:        "prod",
+					Labels:      tt.labels,
+					Annotations: tt.annotations,
+				},
+			}))
+		})
+	}
+}
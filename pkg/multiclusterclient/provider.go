@@ -31,7 +31,7 @@ func (c *ClientProvider) GetClusterClient(
 		return c.internalClusterClient, nil
 	}
 
-	secret, err := c.getClusterSecret(ctx, clusterName, secretNamespace)
+	secret, err := c.GetClusterSecret(ctx, clusterName, secretNamespace)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +53,8 @@ func (c *ClientProvider) GetClusterClient(
 	return cl, nil
 }
 
-func (c *ClientProvider) getClusterSecret(
+// GetClusterSecret returns the secret with connection configuration of the cluster.
+func (c *ClientProvider) GetClusterSecret(
 	ctx context.Context,
 	clusterName string,
 	secretNamespace string,
@@ -0,0 +1,144 @@
+package namespaceprofile
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// LabelsKey is a ConfigMap key with the namespace labels in YAML format.
+	LabelsKey = "labels"
+
+	// AnnotationsKey is a ConfigMap key with the namespace annotations in YAML format.
+	AnnotationsKey = "annotations"
+
+	// ResourceQuotaKey is a ConfigMap key with the ResourceQuota spec in YAML format.
+	ResourceQuotaKey = "resourceQuota"
+
+	// LimitRangeKey is a ConfigMap key with the LimitRange spec in YAML format.
+	LimitRangeKey = "limitRange"
+
+	// DefaultDenyNetworkPolicyKey is a ConfigMap key that enables the NetworkPolicy denying all ingress traffic.
+	DefaultDenyNetworkPolicyKey = "defaultDenyNetworkPolicy"
+
+	// PodSecurityEnforceKey is a ConfigMap key with the Pod Security Admission enforce level.
+	PodSecurityEnforceKey = "podSecurityEnforce"
+
+	// PodSecurityAuditKey is a ConfigMap key with the Pod Security Admission audit level.
+	PodSecurityAuditKey = "podSecurityAudit"
+
+	// PodSecurityWarnKey is a ConfigMap key with the Pod Security Admission warn level.
+	PodSecurityWarnKey = "podSecurityWarn"
+
+	// ResourceName is a name of the ResourceQuota and LimitRange created from the profile.
+	ResourceName = "namespace-profile"
+
+	// DefaultDenyNetworkPolicyName is a name of the NetworkPolicy denying all ingress traffic.
+	DefaultDenyNetworkPolicyName = "default-deny-ingress"
+
+	// ProfileLabel is set to the resources created from the profile. The value is the profile name.
+	ProfileLabel = "app.edp.epam.com/namespace-profile"
+
+	// ManagedLabelsAnnotation contains a comma-separated list of the namespace labels set from the profile.
+	// It is used to remove the labels that are dropped from the profile.
+	ManagedLabelsAnnotation = "app.edp.epam.com/namespace-profile-labels"
+
+	// ManagedAnnotationsAnnotation contains a comma-separated list of the namespace annotations set from the profile.
+	// It is used to remove the annotations that are dropped from the profile.
+	ManagedAnnotationsAnnotation = "app.edp.epam.com/namespace-profile-annotations"
+
+	podSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+	podSecurityAuditLabel   = "pod-security.kubernetes.io/audit"
+	podSecurityWarnLabel    = "pod-security.kubernetes.io/warn"
+)
+
+// Profile defines the configuration applied to the Stage namespace.
+type Profile struct {
+	// Name of the profile.
+	Name string
+
+	// Labels that are added to the namespace, including the Pod Security Admission labels.
+	Labels map[string]string
+
+	// Annotations that are added to the namespace.
+	Annotations map[string]string
+
+	// ResourceQuota spec. If nil, ResourceQuota is not created.
+	ResourceQuota *corev1.ResourceQuotaSpec
+
+	// LimitRange spec. If nil, LimitRange is not created.
+	LimitRange *corev1.LimitRangeSpec
+
+	// DefaultDenyNetworkPolicy enables the NetworkPolicy that denies all ingress traffic to the namespace.
+	DefaultDenyNetworkPolicy bool
+}
+
+// FromConfigMap parses the namespace profile from the ConfigMap.
+func FromConfigMap(cm *corev1.ConfigMap) (*Profile, error) {
+	profile := &Profile{
+		Name:        cm.Name,
+		Labels:      map[string]string{},
+		Annotations: map[string]string{},
+	}
+
+	if err := unmarshalKey(cm, LabelsKey, &profile.Labels); err != nil {
+		return nil, err
+	}
+
+	if err := unmarshalKey(cm, AnnotationsKey, &profile.Annotations); err != nil {
+		return nil, err
+	}
+
+	if _, ok := cm.Data[ResourceQuotaKey]; ok {
+		profile.ResourceQuota = &corev1.ResourceQuotaSpec{}
+
+		if err := unmarshalKey(cm, ResourceQuotaKey, profile.ResourceQuota); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := cm.Data[LimitRangeKey]; ok {
+		profile.LimitRange = &corev1.LimitRangeSpec{}
+
+		if err := unmarshalKey(cm, LimitRangeKey, profile.LimitRange); err != nil {
+			return nil, err
+		}
+	}
+
+	if val, ok := cm.Data[DefaultDenyNetworkPolicyKey]; ok {
+		enabled, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s of namespace profile %s: %w", DefaultDenyNetworkPolicyKey, cm.Name, err)
+		}
+
+		profile.DefaultDenyNetworkPolicy = enabled
+	}
+
+	for key, label := range map[string]string{
+		PodSecurityEnforceKey: podSecurityEnforceLabel,
+		PodSecurityAuditKey:   podSecurityAuditLabel,
+		PodSecurityWarnKey:    podSecurityWarnLabel,
+	} {
+		if level := cm.Data[key]; level != "" {
+			profile.Labels[label] = level
+		}
+	}
+
+	return profile, nil
+}
+
+func unmarshalKey(cm *corev1.ConfigMap, key string, out any) error {
+	val, ok := cm.Data[key]
+	if !ok {
+		return nil
+	}
+
+	if err := yaml.UnmarshalStrict([]byte(val), out); err != nil {
+		return fmt.Errorf("failed to parse %s of namespace profile %s: %w", key, cm.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package namespaceprofile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFromConfigMap(t *testing.T) {
+	t.Parallel()
+
+	newConfigMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "restricted",
+				Namespace: "default",
+			},
+			Data: data,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		cm      *corev1.ConfigMap
+		want    *Profile
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "full profile",
+			cm: newConfigMap(map[string]string{
+				LabelsKey:      "team: backend\n",
+				AnnotationsKey: "owner: backend@example.com\n",
+				ResourceQuotaKey: `
+hard:
+  pods: "10"
+`,
+				LimitRangeKey: `
+limits:
+- type: Container
+  default:
+    memory: 512Mi
+`,
+				DefaultDenyNetworkPolicyKey: "true",
+				PodSecurityEnforceKey:       "restricted",
+				PodSecurityWarnKey:          "baseline",
+			}),
+			want: &Profile{
+				Name: "restricted",
+				Labels: map[string]string{
+					"team":                               "backend",
+					"pod-security.kubernetes.io/enforce": "restricted",
+					"pod-security.kubernetes.io/warn":    "baseline",
+				},
+				Annotations: map[string]string{
+					"owner": "backend@example.com",
+				},
+				ResourceQuota: &corev1.ResourceQuotaSpec{
+					Hard: corev1.ResourceList{
+						corev1.ResourcePods: resource.MustParse("10"),
+					},
+				},
+				LimitRange: &corev1.LimitRangeSpec{
+					Limits: []corev1.LimitRangeItem{
+						{
+							Type: corev1.LimitTypeContainer,
+							Default: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("512Mi"),
+							},
+						},
+					},
+				},
+				DefaultDenyNetworkPolicy: true,
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "empty profile",
+			cm:   newConfigMap(nil),
+			want: &Profile{
+				Name:        "restricted",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "invalid labels",
+			cm: newConfigMap(map[string]string{
+				LabelsKey: "- team",
+			}),
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to parse labels of namespace profile restricted")
+			},
+		},
+		{
+			name: "unknown resource quota field",
+			cm: newConfigMap(map[string]string{
+				ResourceQuotaKey: "limits: {}",
+			}),
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to parse resourceQuota")
+			},
+		},
+		{
+			name: "invalid network policy flag",
+			cm: newConfigMap(map[string]string{
+				DefaultDenyNetworkPolicyKey: "yes please",
+			}),
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to parse defaultDenyNetworkPolicy")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FromConfigMap(tt.cm)
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -41,12 +41,16 @@ func NewStageBatchModifier(k8sClient client.Client, modifiers []StageModifier) *
 
 // NewStageBatchModifierAll returns a new instance of StageBatchModifier with all the modifiers.
 func NewStageBatchModifierAll(k8sClient client.Client, scheme *runtime.Scheme) *StageBatchModifier {
-	modifiers := []StageModifier{
+	return &StageBatchModifier{k8sClient: k8sClient, modifiers: NewStageModifiers(k8sClient, scheme)}
+}
+
+// NewStageModifiers returns all the modifiers that set default values to the stage.
+// Modifiers only change the object, they don't patch it.
+func NewStageModifiers(k8sClient client.Client, scheme *runtime.Scheme) []StageModifier {
+	return []StageModifier{
 		StageModifierFunc(setStageLabel),
 		newStageOwnerRefModifier(k8sClient, scheme),
 	}
-
-	return &StageBatchModifier{k8sClient: k8sClient, modifiers: modifiers}
 }
 
 // Apply applies all the modifiers to the stage.
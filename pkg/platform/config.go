@@ -3,6 +3,7 @@ package platform
 import (
 	"context"
 	"fmt"
+	"os"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -13,6 +14,15 @@ const (
 	KrciConfigMap                    = "krci-config"
 	KrciConfigContainerRegistryHost  = "container_registry_host"
 	KrciConfigContainerRegistrySpace = "container_registry_space"
+
+	// KrciConfigSecretManager is a key of the tenant secret manager. Possible values: own, eso, none.
+	KrciConfigSecretManager = "secret_manager"
+
+	// SecretManagerEnv is a default secret manager of all tenants.
+	SecretManagerEnv  = "SECRET_MANAGER"
+	SecretManagerESO  = "eso"
+	SecretManagerOwn  = "own"
+	SecretManagerNone = "none"
 )
 
 type KrciConfig struct {
@@ -41,6 +51,45 @@ func GetKrciConfig(ctx context.Context, k8sClient client.Client, namespace strin
 	return mapConfigToKrciConfig(config)
 }
 
+// GetSecretManager returns the secret manager of the tenant namespace.
+// The secret_manager key of the krci-config ConfigMap overrides the SECRET_MANAGER environment variable.
+func GetSecretManager(ctx context.Context, k8sClient client.Client, namespace string) (string, error) {
+	config := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{
+		Namespace: namespace,
+		Name:      KrciConfigMap,
+	}, config); err != nil {
+		if errors.IsNotFound(err) {
+			return os.Getenv(SecretManagerEnv), nil
+		}
+
+		return "", fmt.Errorf("failed to get %s: %w", KrciConfigMap, err)
+	}
+
+	if secretManager := config.Data[KrciConfigSecretManager]; secretManager != "" {
+		if err := ValidateSecretManager(secretManager); err != nil {
+			return "", fmt.Errorf("invalid %s in %s: %w", KrciConfigSecretManager, KrciConfigMap, err)
+		}
+
+		return secretManager, nil
+	}
+
+	return os.Getenv(SecretManagerEnv), nil
+}
+
+// ValidateSecretManager checks that the secret manager is one of own, eso or none.
+func ValidateSecretManager(secretManager string) error {
+	switch secretManager {
+	case SecretManagerOwn, SecretManagerESO, SecretManagerNone:
+		return nil
+	default:
+		return fmt.Errorf(
+			"unknown secret manager %q, expected one of %s, %s, %s",
+			secretManager, SecretManagerOwn, SecretManagerESO, SecretManagerNone,
+		)
+	}
+}
+
 // getEdpConfig is backward compatibility for edp-config config map.
 // Deprecated: use GetKrciConfig instead.
 // TODO: remove this function after all instances will be migrated to krci-config.
@@ -83,3 +83,83 @@ func TestGetKrciConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSecretManager(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    string
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "secret manager is set in krci config",
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      KrciConfigMap,
+						Namespace: "default",
+					},
+					Data: map[string]string{
+						KrciConfigSecretManager: SecretManagerOwn,
+					},
+				},
+			},
+			want:    SecretManagerOwn,
+			wantErr: require.NoError,
+		},
+		{
+			name: "secret manager in krci config is unknown",
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      KrciConfigMap,
+						Namespace: "default",
+					},
+					Data: map[string]string{
+						KrciConfigSecretManager: "vault",
+					},
+				},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unknown secret manager \"vault\"")
+			},
+		},
+		{
+			name: "secret manager is not set in krci config",
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      KrciConfigMap,
+						Namespace: "default",
+					},
+				},
+			},
+			want:    SecretManagerESO,
+			wantErr: require.NoError,
+		},
+		{
+			name:    "krci config doesn't exist",
+			want:    SecretManagerESO,
+			wantErr: require.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(SecretManagerEnv, SecretManagerESO)
+
+			got, err := GetSecretManager(
+				context.Background(),
+				fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+				"default",
+			)
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
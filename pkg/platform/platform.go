@@ -1,8 +1,11 @@
 package platform
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -12,10 +15,45 @@ const (
 	Openshift              = "openshift"
 	Kubernetes             = "kubernetes"
 	TenancyEngineCapsule   = "capsule"
+	TenancyEngineHNC       = "hnc"
 	OIDCAdminGroupName     = "OIDC_ADMIN_GROUP_NAME"
 	OIDCDeveloperGroupName = "OIDC_DEVELOPER_GROUP_NAME"
+	StageNamespaceTemplate = "STAGE_NAMESPACE_TEMPLATE"
+
+	// StageNamespaceAllowedPatterns is a JSON list of regular expressions the Stage target namespace should match.
+	StageNamespaceAllowedPatterns = "STAGE_NAMESPACE_ALLOWED_PATTERNS"
+
+	// StageNamespaceReserved is a comma-separated list of namespaces that can't be used by Stages.
+	StageNamespaceReserved = "STAGE_NAMESPACE_RESERVED"
+
+	// EditProtectionBypassGroups is a comma-separated list of groups that can bypass the edit-protection label.
+	EditProtectionBypassGroups = "EDIT_PROTECTION_BYPASS_GROUPS"
+
+	// CapsuleTenantName is a name of the Capsule Tenant the Stage namespaces are assigned to.
+	CapsuleTenantName = "CAPSULE_TENANT_NAME"
+
+	// HNCParentNamespace is a name of the parent namespace for the Stage subnamespaces.
+	HNCParentNamespace = "HNC_PARENT_NAMESPACE"
+
+	// OpenshiftProjectRequester is a value of the requester annotation of the Stage OpenShift projects.
+	OpenshiftProjectRequester = "OPENSHIFT_PROJECT_REQUESTER"
+
+	// ImagePullSecrets is a comma-separated list of the default image pull secrets of the Stage namespaces.
+	ImagePullSecrets = "IMAGE_PULL_SECRETS"
+
+	// DefaultImagePullSecret is a default Secret with the container registry credentials.
+	DefaultImagePullSecret = "regcred"
+
+	// AccessControlAllowedRoles is a comma-separated list of the roles that the Stage access control can bind.
+	AccessControlAllowedRoles = "ACCESS_CONTROL_ALLOWED_ROLES"
+
+	// DefaultStageNamespaceTemplate is a default template for the Stage target namespace.
+	DefaultStageNamespaceTemplate = "{{ .Tenant }}-{{ .CDPipeline }}-{{ .Stage }}"
 )
 
+// defaultReservedNamespaces are system namespaces that can't be used by Stages.
+var defaultReservedNamespaces = []string{"default", "kube-system", "kube-public", "kube-node-lease"}
+
 func GetPlatformTypeEnv() string {
 	pt, ok := os.LookupEnv(TypeEnv)
 	if ok {
@@ -40,6 +78,11 @@ func CapsuleEnabled() bool {
 	return os.Getenv(TenancyEngineEnv) == TenancyEngineCapsule
 }
 
+// HNCEnabled returns true if the Hierarchical Namespace Controller is enabled.
+func HNCEnabled() bool {
+	return os.Getenv(TenancyEngineEnv) == TenancyEngineHNC
+}
+
 // ManageNamespace returns true if namespace should be managed by the operator.
 // If the environment variable MANAGE_NAMESPACE is not set, it returns true.
 func ManageNamespace() bool {
@@ -65,3 +108,134 @@ func GetOIDCAdminGroupName() string {
 func GetOIDCDeveloperGroupName() string {
 	return os.Getenv(OIDCDeveloperGroupName)
 }
+
+// GetStageNamespaceTemplate returns the template for the Stage target namespace.
+// If the environment variable STAGE_NAMESPACE_TEMPLATE is not set, it returns the default template.
+func GetStageNamespaceTemplate() string {
+	if tmpl := os.Getenv(StageNamespaceTemplate); tmpl != "" {
+		return tmpl
+	}
+
+	return DefaultStageNamespaceTemplate
+}
+
+// GetStageNamespaceAllowedPatterns returns the list of patterns the Stage target namespace should match.
+// If the environment variable STAGE_NAMESPACE_ALLOWED_PATTERNS is not set, it returns an empty list.
+func GetStageNamespaceAllowedPatterns() ([]string, error) {
+	val := os.Getenv(StageNamespaceAllowedPatterns)
+	if val == "" {
+		return nil, nil
+	}
+
+	var patterns []string
+	if err := json.Unmarshal([]byte(val), &patterns); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", StageNamespaceAllowedPatterns, err)
+	}
+
+	return patterns, nil
+}
+
+// GetStageNamespaceReserved returns the list of namespaces that can't be used by Stages.
+// The system namespaces are always reserved, the environment variable STAGE_NAMESPACE_RESERVED extends the list.
+func GetStageNamespaceReserved() []string {
+	reserved := append([]string{}, defaultReservedNamespaces...)
+
+	for _, ns := range strings.Split(os.Getenv(StageNamespaceReserved), ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			reserved = append(reserved, ns)
+		}
+	}
+
+	return reserved
+}
+
+// GetEditProtectionBypassGroups returns the list of groups that can bypass the edit-protection label.
+// The OIDC admin group is always included if it is set.
+func GetEditProtectionBypassGroups() []string {
+	var groups []string
+
+	if admin := GetOIDCAdminGroupName(); admin != "" {
+		groups = append(groups, admin)
+	}
+
+	for _, group := range strings.Split(os.Getenv(EditProtectionBypassGroups), ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
+// GetCapsuleTenantName returns the name of the Capsule Tenant the Stage namespaces are assigned to.
+// If the environment variable CAPSULE_TENANT_NAME is not set,
+// it returns the name of the Tenant created by the operator chart for the given namespace.
+func GetCapsuleTenantName(namespace string) string {
+	if name := os.Getenv(CapsuleTenantName); name != "" {
+		return name
+	}
+
+	return "edp-workload-" + namespace
+}
+
+// GetHNCParentNamespace returns the name of the parent namespace for the Stage subnamespaces.
+// HNC propagates Roles, RoleBindings and, if enabled, Secrets from the parent namespace to the subnamespaces,
+// so the parent namespace should be set explicitly with the environment variable HNC_PARENT_NAMESPACE
+// and must not be the given tenant namespace that holds cluster credentials.
+func GetHNCParentNamespace(namespace string) (string, error) {
+	parent := os.Getenv(HNCParentNamespace)
+	if parent == "" {
+		return "", fmt.Errorf("%s is not set, a dedicated parent namespace is required for HNC", HNCParentNamespace)
+	}
+
+	if parent == namespace {
+		return "", fmt.Errorf(
+			"%s can't be the tenant namespace %s, a dedicated parent namespace is required for HNC",
+			HNCParentNamespace,
+			namespace,
+		)
+	}
+
+	return parent, nil
+}
+
+// GetOpenshiftProjectRequester returns the requester of the Stage OpenShift projects.
+func GetOpenshiftProjectRequester() string {
+	return os.Getenv(OpenshiftProjectRequester)
+}
+
+// GetImagePullSecrets returns the list of the default image pull secrets of the Stage namespaces.
+// If the environment variable IMAGE_PULL_SECRETS is not set, it returns the regcred Secret.
+func GetImagePullSecrets() []string {
+	var secrets []string
+
+	for _, secret := range strings.Split(os.Getenv(ImagePullSecrets), ",") {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	if len(secrets) == 0 {
+		return []string{DefaultImagePullSecret}
+	}
+
+	return secrets
+}
+
+// GetAccessControlAllowedRoles returns the list of the roles that the Stage access control can bind.
+// If the environment variable ACCESS_CONTROL_ALLOWED_ROLES is not set, it returns the admin, edit and view roles.
+func GetAccessControlAllowedRoles() []string {
+	var roles []string
+
+	for _, role := range strings.Split(os.Getenv(AccessControlAllowedRoles), ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+
+	if len(roles) == 0 {
+		return []string{"admin", "edit", "view"}
+	}
+
+	return roles
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetPlatformTypeEnv_Success(t *testing.T) {
@@ -165,3 +166,251 @@ func TestGetOIDCDeveloperGroupName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetStageNamespaceTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     string
+	}{
+		{
+			name:     "custom template",
+			envValue: "{{ .Tenant }}-{{ .Name }}",
+			want:     "{{ .Tenant }}-{{ .Name }}",
+		},
+		{
+			name:     "default template",
+			envValue: "",
+			want:     DefaultStageNamespaceTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(StageNamespaceTemplate, tt.envValue)
+
+			assert.Equal(t, tt.want, GetStageNamespaceTemplate())
+		})
+	}
+}
+
+func TestGetStageNamespaceAllowedPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     []string
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			name:     "patterns are set",
+			envValue: `["^{{ .Tenant }}-.+$", "^team-[a-z]{2,5}$"]`,
+			want:     []string{"^{{ .Tenant }}-.+$", "^team-[a-z]{2,5}$"},
+			wantErr:  require.NoError,
+		},
+		{
+			name:     "patterns are not set",
+			envValue: "",
+			want:     nil,
+			wantErr:  require.NoError,
+		},
+		{
+			name:     "invalid value",
+			envValue: "^team-.+$",
+			want:     nil,
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to parse STAGE_NAMESPACE_ALLOWED_PATTERNS")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(StageNamespaceAllowedPatterns, tt.envValue)
+
+			got, err := GetStageNamespaceAllowedPatterns()
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetStageNamespaceReserved(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     []string
+	}{
+		{
+			name:     "additional namespaces are set",
+			envValue: "argocd, monitoring,,",
+			want:     []string{"default", "kube-system", "kube-public", "kube-node-lease", "argocd", "monitoring"},
+		},
+		{
+			name:     "additional namespaces are not set",
+			envValue: "",
+			want:     []string{"default", "kube-system", "kube-public", "kube-node-lease"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(StageNamespaceReserved, tt.envValue)
+
+			assert.Equal(t, tt.want, GetStageNamespaceReserved())
+		})
+	}
+}
+
+func TestGetEditProtectionBypassGroups(t *testing.T) {
+	tests := []struct {
+		name       string
+		adminGroup string
+		envValue   string
+		want       []string
+	}{
+		{
+			name:       "admin and additional groups are set",
+			adminGroup: "oidc-admins",
+			envValue:   "platform-admins, sre,",
+			want:       []string{"oidc-admins", "platform-admins", "sre"},
+		},
+		{
+			name:     "only additional groups are set",
+			envValue: "platform-admins",
+			want:     []string{"platform-admins"},
+		},
+		{
+			name: "groups are not set",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(OIDCAdminGroupName, tt.adminGroup)
+			t.Setenv(EditProtectionBypassGroups, tt.envValue)
+
+			assert.Equal(t, tt.want, GetEditProtectionBypassGroups())
+		})
+	}
+}
+
+func TestGetCapsuleTenantName(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     string
+	}{
+		{
+			name:     "custom tenant",
+			envValue: "team-tenant",
+			want:     "team-tenant",
+		},
+		{
+			name:     "default tenant",
+			envValue: "",
+			want:     "edp-workload-krci",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(CapsuleTenantName, tt.envValue)
+
+			assert.Equal(t, tt.want, GetCapsuleTenantName("krci"))
+		})
+	}
+}
+
+func TestGetHNCParentNamespace(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     string
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			name:     "dedicated parent namespace",
+			envValue: "krci-stages",
+			want:     "krci-stages",
+			wantErr:  require.NoError,
+		},
+		{
+			name:     "parent namespace is not set",
+			envValue: "",
+			wantErr:  require.Error,
+		},
+		{
+			name:     "parent namespace is the tenant namespace",
+			envValue: "krci",
+			wantErr:  require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(HNCParentNamespace, tt.envValue)
+
+			got, err := GetHNCParentNamespace("krci")
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetImagePullSecrets(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     []string
+	}{
+		{
+			name:     "image pull secrets are set",
+			envValue: "regcred, quay-creds,,",
+			want:     []string{"regcred", "quay-creds"},
+		},
+		{
+			name:     "image pull secrets are not set",
+			envValue: "",
+			want:     []string{"regcred"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ImagePullSecrets, tt.envValue)
+
+			assert.Equal(t, tt.want, GetImagePullSecrets())
+		})
+	}
+}
+
+func TestGetAccessControlAllowedRoles(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     []string
+	}{
+		{
+			name:     "allowed roles are set",
+			envValue: "view, app-deployer,,",
+			want:     []string{"view", "app-deployer"},
+		},
+		{
+			name:     "allowed roles are not set",
+			envValue: "",
+			want:     []string{"admin", "edit", "view"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(AccessControlAllowedRoles, tt.envValue)
+
+			assert.Equal(t, tt.want, GetAccessControlAllowedRoles())
+		})
+	}
+}
@@ -3,9 +3,11 @@ package rbac
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/go-logr/logr"
 	rbacApi "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -16,6 +18,11 @@ const (
 	crNameLogKey    = "name"
 	ClusterRoleKind = "ClusterRole"
 	RoleKind        = "Role"
+
+	// ManagedByLabel is a label of the RoleBindings that are managed by the operator.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedByValue is a value of the ManagedByLabel.
+	ManagedByValue = "edp-cd-pipeline-operator"
 )
 
 type Manager interface {
@@ -29,6 +36,10 @@ type Manager interface {
 		subjects []rbacApi.Subject,
 		roleRef rbacApi.RoleRef,
 	) error
+	ApplyRoleBinding(ctx context.Context, rb *rbacApi.RoleBinding) error
+	ListRoleBindings(ctx context.Context, namespace string, opts ...client.ListOption) ([]rbacApi.RoleBinding, error)
+	DeleteRoleBinding(ctx context.Context, name, namespace string) error
+	DeleteStaleRoleBindings(ctx context.Context, namespace string, keep []string, opts ...client.ListOption) error
 	GetRole(name, namespace string) (*rbacApi.Role, error)
 	CreateRole(name, namespace string, rules []rbacApi.PolicyRule) error
 }
@@ -129,6 +140,153 @@ func (s KubernetesRbac) CreateRoleBindingIfNotExists(
 	return s.CreateRoleBinding(name, namespace, subjects, roleRef)
 }
 
+// ApplyRoleBinding creates the RoleBinding or updates its subjects and labels if they have drifted.
+// RoleRef of the RoleBinding is immutable, so the RoleBinding is recreated if RoleRef is changed.
+// The RoleBinding is labeled with ManagedByLabel to distinguish it from the RoleBindings created by others.
+// Only the Stage access control RoleBindings are garbage-collected by DeleteStaleRoleBindings,
+// other RoleBindings, e.g. tenant-admin, are deleted by name.
+func (s KubernetesRbac) ApplyRoleBinding(ctx context.Context, rb *rbacApi.RoleBinding) error {
+	log := s.log.WithValues(crNameLogKey, rb.Name, "namespace", rb.Namespace)
+
+	desired := rb.DeepCopy()
+	if desired.Labels == nil {
+		desired.Labels = make(map[string]string, 1)
+	}
+
+	desired.Labels[ManagedByLabel] = ManagedByValue
+
+	current := &rbacApi.RoleBinding{}
+
+	err := s.client.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, current)
+	if err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get role binding: %w", err)
+		}
+
+		log.Info("Creating RoleBinding")
+
+		if err = s.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create role binding: %w", err)
+		}
+
+		return nil
+	}
+
+	if current.RoleRef != desired.RoleRef {
+		log.Info("RoleRef of RoleBinding has been changed, recreating RoleBinding")
+
+		if err = s.client.Delete(ctx, current); err != nil && !k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete role binding: %w", err)
+		}
+
+		if err = s.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create role binding: %w", err)
+		}
+
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(current.Subjects, desired.Subjects) && hasLabels(current.Labels, desired.Labels) {
+		return nil
+	}
+
+	log.Info("Updating RoleBinding")
+
+	if current.Labels == nil {
+		current.Labels = make(map[string]string, len(desired.Labels))
+	}
+
+	for k, v := range desired.Labels {
+		current.Labels[k] = v
+	}
+
+	current.Subjects = desired.Subjects
+
+	if err = s.client.Update(ctx, current); err != nil {
+		return fmt.Errorf("failed to update role binding: %w", err)
+	}
+
+	return nil
+}
+
+// ListRoleBindings returns RoleBindings in the given namespace.
+func (s KubernetesRbac) ListRoleBindings(
+	ctx context.Context,
+	namespace string,
+	opts ...client.ListOption,
+) ([]rbacApi.RoleBinding, error) {
+	list := &rbacApi.RoleBindingList{}
+	listOpts := append([]client.ListOption{client.InNamespace(namespace)}, opts...)
+
+	if err := s.client.List(ctx, list, listOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list role bindings: %w", err)
+	}
+
+	return list.Items, nil
+}
+
+// DeleteRoleBinding deletes the RoleBinding if it exists in the given namespace.
+func (s KubernetesRbac) DeleteRoleBinding(ctx context.Context, name, namespace string) error {
+	log := s.log.WithValues(crNameLogKey, name, "namespace", namespace)
+
+	err := s.client.Delete(ctx, &rbacApi.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to delete role binding: %w", err)
+	}
+
+	log.Info("RoleBinding has been deleted")
+
+	return nil
+}
+
+// DeleteStaleRoleBindings deletes RoleBindings managed by the operator in the given namespace
+// that match the given options and are not in the keep list.
+// Callers should narrow the list with options, so RoleBindings of other features are not deleted.
+func (s KubernetesRbac) DeleteStaleRoleBindings(
+	ctx context.Context,
+	namespace string,
+	keep []string,
+	opts ...client.ListOption,
+) error {
+	listOpts := append([]client.ListOption{client.MatchingLabels{ManagedByLabel: ManagedByValue}}, opts...)
+
+	rbs, err := s.ListRoleBindings(ctx, namespace, listOpts...)
+	if err != nil {
+		return err
+	}
+
+	for i := range rbs {
+		if slices.Contains(keep, rbs[i].Name) {
+			continue
+		}
+
+		if err = s.DeleteRoleBinding(ctx, rbs[i].Name, namespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasLabels(labels, expected map[string]string) bool {
+	for k, v := range expected {
+		if labels[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (s KubernetesRbac) GetRole(name, namespace string) (*rbacApi.Role, error) {
 	log := s.log.WithValues(crNameLogKey, name, "namespace", namespace)
 	log.Info("getting role binding")
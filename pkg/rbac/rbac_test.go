@@ -277,3 +277,189 @@ func TestKubernetesRbac_CreateRoleBindingIfNotExists(t *testing.T) {
 		})
 	}
 }
+
+func TestKubernetesRbac_ApplyRoleBinding(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, rbacApi.AddToScheme(scheme))
+
+	newRoleBinding := func(subject, role string) *rbacApi.RoleBinding {
+		return &rbacApi.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-role-binding",
+				Namespace: "test-namespace",
+				Labels:    map[string]string{"app": "test"},
+			},
+			Subjects: []rbacApi.Subject{
+				{
+					APIGroup: rbacApi.GroupName,
+					Kind:     rbacApi.GroupKind,
+					Name:     subject,
+				},
+			},
+			RoleRef: rbacApi.RoleRef{
+				APIGroup: rbacApi.GroupName,
+				Kind:     ClusterRoleKind,
+				Name:     role,
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		roleBinding *rbacApi.RoleBinding
+		objects     []client.Object
+		wantErr     require.ErrorAssertionFunc
+		wantCheck   func(t *testing.T, k8sClient client.Client)
+	}{
+		{
+			name:        "RoleBinding is created",
+			roleBinding: newRoleBinding("developers", "view"),
+			wantErr:     require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				rb := &rbacApi.RoleBinding{}
+				require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{
+					Namespace: "test-namespace",
+					Name:      "test-role-binding",
+				}, rb))
+
+				assert.Equal(t, "developers", rb.Subjects[0].Name)
+				assert.Equal(t, "view", rb.RoleRef.Name)
+				assert.Equal(t, ManagedByValue, rb.Labels[ManagedByLabel])
+			},
+		},
+		{
+			name:        "RoleBinding subjects are updated",
+			roleBinding: newRoleBinding("admins", "view"),
+			objects:     []client.Object{newRoleBinding("developers", "view")},
+			wantErr:     require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				rb := &rbacApi.RoleBinding{}
+				require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{
+					Namespace: "test-namespace",
+					Name:      "test-role-binding",
+				}, rb))
+
+				assert.Equal(t, "admins", rb.Subjects[0].Name)
+				assert.Equal(t, "view", rb.RoleRef.Name)
+			},
+		},
+		{
+			name:        "RoleBinding is recreated if RoleRef is changed",
+			roleBinding: newRoleBinding("developers", "edit"),
+			objects:     []client.Object{newRoleBinding("developers", "view")},
+			wantErr:     require.NoError,
+			wantCheck: func(t *testing.T, k8sClient client.Client) {
+				rb := &rbacApi.RoleBinding{}
+				require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{
+					Namespace: "test-namespace",
+					Name:      "test-role-binding",
+				}, rb))
+
+				assert.Equal(t, "developers", rb.Subjects[0].Name)
+				assert.Equal(t, "edit", rb.RoleRef.Name)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+			s := NewRbacManager(k8sClient, logr.Discard())
+
+			err := s.ApplyRoleBinding(context.Background(), tt.roleBinding)
+
+			tt.wantErr(t, err)
+			tt.wantCheck(t, k8sClient)
+		})
+	}
+}
+
+func TestKubernetesRbac_DeleteRoleBinding(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, rbacApi.AddToScheme(scheme))
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+	}{
+		{
+			name: "RoleBinding is deleted",
+			objects: []client.Object{
+				&rbacApi.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-role-binding",
+						Namespace: "test-namespace",
+					},
+				},
+			},
+		},
+		{
+			name: "RoleBinding does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+			s := NewRbacManager(k8sClient, logr.Discard())
+
+			require.NoError(t, s.DeleteRoleBinding(context.Background(), "test-role-binding", "test-namespace"))
+
+			list, err := s.ListRoleBindings(context.Background(), "test-namespace")
+			require.NoError(t, err)
+			assert.Empty(t, list)
+		})
+	}
+}
+
+func TestKubernetesRbac_DeleteStaleRoleBindings(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, rbacApi.AddToScheme(scheme))
+
+	newRoleBinding := func(name string, labels map[string]string) *rbacApi.RoleBinding {
+		return &rbacApi.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-namespace",
+				Labels:    labels,
+			},
+		}
+	}
+
+	managed := map[string]string{ManagedByLabel: ManagedByValue, "app": "test"}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newRoleBinding("managed-keep", managed),
+		newRoleBinding("managed-stale", managed),
+		newRoleBinding("managed-other", map[string]string{ManagedByLabel: ManagedByValue}),
+		newRoleBinding("unmanaged", map[string]string{"app": "test"}),
+	).Build()
+	s := NewRbacManager(k8sClient, logr.Discard())
+
+	require.NoError(t, s.DeleteStaleRoleBindings(
+		context.Background(),
+		"test-namespace",
+		[]string{"managed-keep"},
+		client.MatchingLabels{"app": "test"},
+	))
+
+	list, err := s.ListRoleBindings(context.Background(), "test-namespace")
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(list))
+	for i := range list {
+		names = append(names, list[i].Name)
+	}
+
+	assert.ElementsMatch(t, []string{"managed-keep", "managed-other", "unmanaged"}, names)
+}
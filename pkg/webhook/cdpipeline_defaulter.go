@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+// +kubebuilder:webhook:path=/mutate-v2-edp-epam-com-v1-cdpipeline,mutating=true,failurePolicy=fail,sideEffects=None,groups=v2.edp.epam.com,resources=cdpipelines,verbs=create;update,versions=v1,name=mcdpipeline.epam.com,admissionReviewVersions=v1
+
+// CDPipelineDefaulter is a webhook for setting default values to CDPipeline CRD.
+type CDPipelineDefaulter struct{}
+
+// NewCDPipelineDefaulter creates a new webhook for setting default values to CDPipeline CR.
+func NewCDPipelineDefaulter() *CDPipelineDefaulter {
+	return &CDPipelineDefaulter{}
+}
+
+// SetupWebhookWithManager sets up the defaulting webhook with the manager for CDPipeline CR.
+func (r *CDPipelineDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(&pipelineApi.CDPipeline{}).
+		WithDefaulter(r).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("failed to build CDPipeline defaulting webhook: %w", err)
+	}
+
+	return nil
+}
+
+var _ webhook.CustomDefaulter = &CDPipelineDefaulter{}
+
+// Default sets default values and the user annotations to the CDPipeline.
+func (*CDPipelineDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	pipeline, ok := obj.(*pipelineApi.CDPipeline)
+	if !ok {
+		return errors.New("the wrong object given, expected CDPipeline")
+	}
+
+	if err := setUserAnnotations(ctx, pipeline, &pipelineApi.CDPipeline{}); err != nil {
+		return fmt.Errorf("failed to set user annotations: %w", err)
+	}
+
+	if pipeline.Spec.ApplicationsToPromote == nil {
+		// currently it is not possible to set default as empty slice in the CRD definition by controller-gen
+		pipeline.Spec.ApplicationsToPromote = []string{}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+func TestCDPipelineDefaulter_Default(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		obj     runtime.Object
+		wantErr require.ErrorAssertionFunc
+		want    []string
+	}{
+		{
+			name:    "should set empty applications to promote",
+			obj:     &pipelineApi.CDPipeline{},
+			wantErr: require.NoError,
+			want:    []string{},
+		},
+		{
+			name: "should keep applications to promote",
+			obj: &pipelineApi.CDPipeline{
+				Spec: pipelineApi.CDPipelineSpec{
+					ApplicationsToPromote: []string{"app"},
+				},
+			},
+			wantErr: require.NoError,
+			want:    []string{"app"},
+		},
+		{
+			name: "should return error if object is not CDPipeline",
+			obj:  &pipelineApi.Stage{},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "the wrong object given, expected CDPipeline")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := NewCDPipelineDefaulter().Default(context.Background(), tt.obj)
+
+			tt.wantErr(t, err)
+
+			if pipeline, ok := tt.obj.(*pipelineApi.CDPipeline); ok {
+				assert.Equal(t, tt.want, pipeline.Spec.ApplicationsToPromote)
+			}
+		})
+	}
+}
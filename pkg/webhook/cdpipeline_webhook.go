@@ -2,27 +2,42 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+
 	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/util/cluster"
 )
 
-// +kubebuilder:webhook:path=/validate-v2-edp-epam-com-v1-cdpipeline,mutating=false,failurePolicy=fail,sideEffects=None,groups=v2.edp.epam.com,resources=cdpipelines,verbs=update;delete,versions=v1,name=cdpipeline.epam.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-v2-edp-epam-com-v1-cdpipeline,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=v2.edp.epam.com,resources=cdpipelines,verbs=create;update;delete,versions=v1,name=cdpipeline.epam.com,admissionReviewVersions=v1
 
 // CDPipelineValidationWebhook is a webhook for validating CDPipeline CRD.
 type CDPipelineValidationWebhook struct {
-	client client.Client
+	client     client.Client
+	protection editProtection
 }
 
 // NewCDPipelineValidationWebhook creates a new webhook for validating CDPipeline CR.
-func NewCDPipelineValidationWebhook(k8sClient client.Client) *CDPipelineValidationWebhook {
-	return &CDPipelineValidationWebhook{client: k8sClient}
+func NewCDPipelineValidationWebhook(
+	k8sClient client.Client,
+	recorder record.EventRecorder,
+) *CDPipelineValidationWebhook {
+	return &CDPipelineValidationWebhook{
+		client:     k8sClient,
+		protection: editProtection{recorder: recorder},
+	}
 }
 
 // SetupWebhookWithManager sets up the webhook with the manager for CDPipeline CR.
@@ -41,13 +56,25 @@ func (r *CDPipelineValidationWebhook) SetupWebhookWithManager(mgr ctrl.Manager)
 var _ webhook.CustomValidator = &CDPipelineValidationWebhook{}
 
 // ValidateCreate is a webhook for validating the creation of the CDPipeline CR.
-func (*CDPipelineValidationWebhook) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
-	return nil, nil
+func (r *CDPipelineValidationWebhook) ValidateCreate(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	pipe, ok := obj.(*pipelineApi.CDPipeline)
+	if !ok {
+		return nil, errors.New("the wrong object given, expected CDPipeline")
+	}
+
+	if err := validateSecretManagerAnnotation(pipe); err != nil {
+		return nil, err
+	}
+
+	return nil, r.validateApplications(ctx, pipe)
 }
 
 // ValidateUpdate is a webhook for validating the updating of the CDPipeline CR.
-func (*CDPipelineValidationWebhook) ValidateUpdate(
-	_ context.Context,
+func (r *CDPipelineValidationWebhook) ValidateUpdate(
+	ctx context.Context,
 	oldObj, newObj runtime.Object,
 ) (admission.Warnings, error) {
 	pipe, ok := newObj.(*pipelineApi.CDPipeline)
@@ -55,10 +82,99 @@ func (*CDPipelineValidationWebhook) ValidateUpdate(
 		return nil, nil
 	}
 
-	return nil, checkResourceProtectionFromModificationOnUpdate(oldObj, pipe)
+	bypass, err := r.protection.checkOnUpdate(ctx, oldObj, pipe)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the annotation only if it is changed to not block updates of the existing CDPipelines.
+	if oldPipe, ok := oldObj.(*pipelineApi.CDPipeline); !ok ||
+		oldPipe.GetAnnotations()[pipelineApi.SecretManagerAnnotation] !=
+			pipe.GetAnnotations()[pipelineApi.SecretManagerAnnotation] {
+		if err = validateSecretManagerAnnotation(pipe); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate applications only if spec is changed
+	// to not block metadata updates when Codebase is removed.
+	if isSpecUpdated(oldObj, pipe) {
+		if err = r.validateApplications(ctx, pipe); err != nil {
+			return nil, err
+		}
+	}
+
+	r.protection.recordBypass(ctx, bypass)
+
+	return nil, nil
 }
 
 // ValidateDelete is a webhook for validating the deleting of the CDPipeline CR.
-func (*CDPipelineValidationWebhook) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return nil, checkResourceProtectionFromModificationOnDelete(obj)
+func (r *CDPipelineValidationWebhook) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	bypass, err := r.protection.checkOnDelete(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	r.protection.recordBypass(ctx, bypass)
+
+	return nil, nil
+}
+
+// validateSecretManagerAnnotation checks that the secret manager annotation of the CDPipeline is supported.
+func validateSecretManagerAnnotation(pipe *pipelineApi.CDPipeline) error {
+	secretManager, ok := pipe.GetAnnotations()[pipelineApi.SecretManagerAnnotation]
+	if !ok {
+		return nil
+	}
+
+	if err := platform.ValidateSecretManager(secretManager); err != nil {
+		return fmt.Errorf("invalid %s annotation: %w", pipelineApi.SecretManagerAnnotation, err)
+	}
+
+	return nil
+}
+
+// validateApplications checks that applications, input docker streams and applications to promote
+// of the CDPipeline are consistent with Codebases and CodebaseImageStreams.
+func (r *CDPipelineValidationWebhook) validateApplications(ctx context.Context, pipe *pipelineApi.CDPipeline) error {
+	for _, app := range pipe.Spec.Applications {
+		codebase := &codebaseApi.Codebase{}
+		if err := r.client.Get(ctx, client.ObjectKey{
+			Namespace: pipe.Namespace,
+			Name:      app,
+		}, codebase); err != nil {
+			if k8sErrors.IsNotFound(err) {
+				return fmt.Errorf("application %s is not found", app)
+			}
+
+			return fmt.Errorf("failed to get application %s: %w", app, err)
+		}
+	}
+
+	for _, app := range pipe.Spec.ApplicationsToPromote {
+		if !slices.Contains(pipe.Spec.Applications, app) {
+			return fmt.Errorf("application %s from applicationsToPromote is not in applications", app)
+		}
+	}
+
+	for _, stream := range pipe.Spec.InputDockerStreams {
+		cis, err := cluster.GetCodebaseImageStreamByCodebaseBaseBranchName(ctx, r.client, stream, pipe.Namespace)
+		if err != nil {
+			return fmt.Errorf("input docker stream %s is invalid: %w", stream, err)
+		}
+
+		if !slices.Contains(pipe.Spec.Applications, cis.Spec.Codebase) {
+			return fmt.Errorf(
+				"input docker stream %s belongs to codebase %s which is not in applications",
+				stream,
+				cis.Spec.Codebase,
+			)
+		}
+	}
+
+	return nil
 }
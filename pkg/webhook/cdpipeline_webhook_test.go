@@ -9,11 +9,174 @@ import (
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+
 	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/util/cluster"
 )
 
+func TestCDPipelineValidationWebhook_ValidateCreate(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, pipelineApi.AddToScheme(scheme))
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+
+	objects := []client.Object{
+		&codebaseApi.Codebase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "app1",
+				Namespace: "default",
+			},
+		},
+		&codebaseApi.Codebase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "app2",
+				Namespace: "default",
+			},
+		},
+		&codebaseApi.CodebaseImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "app1-main",
+				Namespace: "default",
+				Labels: map[string]string{
+					cluster.CodebaseBranchLabel: "app1-main",
+				},
+			},
+			Spec: codebaseApi.CodebaseImageStreamSpec{
+				Codebase: "app1",
+			},
+		},
+		&codebaseApi.CodebaseImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "app3-main",
+				Namespace: "default",
+				Labels: map[string]string{
+					cluster.CodebaseBranchLabel: "app3-main",
+				},
+			},
+			Spec: codebaseApi.CodebaseImageStreamSpec{
+				Codebase: "app3",
+			},
+		},
+	}
+
+	newPipeline := func(spec pipelineApi.CDPipelineSpec) *pipelineApi.CDPipeline {
+		return &pipelineApi.CDPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pipeline",
+				Namespace: "default",
+			},
+			Spec: spec,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		obj     runtime.Object
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "valid CDPipeline",
+			obj: newPipeline(pipelineApi.CDPipelineSpec{
+				Applications:          []string{"app1", "app2"},
+				ApplicationsToPromote: []string{"app1"},
+				InputDockerStreams:    []string{"app1-main"},
+			}),
+			wantErr: require.NoError,
+		},
+		{
+			name: "application doesn't exist",
+			obj: newPipeline(pipelineApi.CDPipelineSpec{
+				Applications:       []string{"app1", "app4"},
+				InputDockerStreams: []string{"app1-main"},
+			}),
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "application app4 is not found")
+			},
+		},
+		{
+			name: "application to promote is not in applications",
+			obj: newPipeline(pipelineApi.CDPipelineSpec{
+				Applications:          []string{"app1"},
+				ApplicationsToPromote: []string{"app2"},
+				InputDockerStreams:    []string{"app1-main"},
+			}),
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "application app2 from applicationsToPromote is not in applications")
+			},
+		},
+		{
+			name: "input docker stream doesn't exist",
+			obj: newPipeline(pipelineApi.CDPipelineSpec{
+				Applications:       []string{"app1"},
+				InputDockerStreams: []string{"app1-develop"},
+			}),
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "input docker stream app1-develop is invalid")
+			},
+		},
+		{
+			name: "input docker stream belongs to another codebase",
+			obj: newPipeline(pipelineApi.CDPipelineSpec{
+				Applications:       []string{"app1"},
+				InputDockerStreams: []string{"app3-main"},
+			}),
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(),
+					"input docker stream app3-main belongs to codebase app3 which is not in applications",
+				)
+			},
+		},
+		{
+			name: "secret manager annotation is unknown",
+			obj: func() *pipelineApi.CDPipeline {
+				p := newPipeline(pipelineApi.CDPipelineSpec{
+					Applications:       []string{"app1"},
+					InputDockerStreams: []string{"app1-main"},
+				})
+				p.Annotations = map[string]string{pipelineApi.SecretManagerAnnotation: "vault"}
+
+				return p
+			}(),
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid app.edp.epam.com/secret-manager annotation")
+			},
+		},
+		{
+			name: "invalid object given",
+			obj:  &codebaseApi.Codebase{},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "the wrong object given, expected CDPipeline")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cd := NewCDPipelineValidationWebhook(
+				fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+				record.NewFakeRecorder(10),
+			)
+			w, err := cd.ValidateCreate(context.Background(), tt.obj)
+			assert.Nil(t, w)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
 func TestCDPipelineValidationWebhook_ValidateUpdate(t *testing.T) {
 	cl := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
 
@@ -78,7 +241,7 @@ func TestCDPipelineValidationWebhook_ValidateUpdate(t *testing.T) {
 					},
 				},
 			},
-			wantErr: require.NoError,
+			wantErr: require.Error,
 		},
 		{
 			name: "validating CDPipeline update without protected label in old object",
@@ -133,11 +296,48 @@ func TestCDPipelineValidationWebhook_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: require.NoError,
 		},
+		{
+			name: "secret manager annotation is changed to unknown value",
+			args: args{
+				oldObj: &pipelineApi.CDPipeline{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "cd-pipeline",
+						Annotations: map[string]string{pipelineApi.SecretManagerAnnotation: "own"},
+					},
+				},
+				newObj: &pipelineApi.CDPipeline{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "cd-pipeline",
+						Annotations: map[string]string{pipelineApi.SecretManagerAnnotation: "vault"},
+					},
+				},
+			},
+			wantErr: require.Error,
+		},
+		{
+			name: "unchanged secret manager annotation is not validated",
+			args: args{
+				oldObj: &pipelineApi.CDPipeline{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "cd-pipeline",
+						Annotations: map[string]string{pipelineApi.SecretManagerAnnotation: "vault"},
+					},
+				},
+				newObj: &pipelineApi.CDPipeline{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "cd-pipeline",
+						Annotations: map[string]string{pipelineApi.SecretManagerAnnotation: "vault"},
+						Finalizers:  []string{"finalizer"},
+					},
+				},
+			},
+			wantErr: require.NoError,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cd := NewCDPipelineValidationWebhook(cl)
+			cd := NewCDPipelineValidationWebhook(cl, record.NewFakeRecorder(10))
 			w, err := cd.ValidateUpdate(context.Background(), tt.args.oldObj, tt.args.newObj)
 			assert.Nil(t, w)
 			tt.wantErr(t, err)
@@ -189,7 +389,7 @@ func TestCDPipelineValidationWebhook_ValidateDelete(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cd := NewCDPipelineValidationWebhook(cl)
+			cd := NewCDPipelineValidationWebhook(cl, record.NewFakeRecorder(10))
 			w, err := cd.ValidateDelete(context.Background(), tt.args.obj)
 			assert.Nil(t, w)
 			tt.wantErr(t, err)
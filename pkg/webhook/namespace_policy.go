@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
+)
+
+// validateNamespacePolicy checks that the Stage target namespace is not reserved
+// and matches one of the allowed patterns. The operator namespace is always reserved.
+// Allowed patterns are rendered with the same data as the namespace template, so they can refer to the Stage fields.
+func validateNamespacePolicy(stage *pipelineApi.Stage, target pipelineApi.ClusterTarget) error {
+	if target.Namespace == stage.Namespace || slices.Contains(platform.GetStageNamespaceReserved(), target.Namespace) {
+		return fmt.Errorf("namespace %s is reserved and can't be used by Stage", target.Namespace)
+	}
+
+	patterns, err := platform.GetStageNamespaceAllowedPatterns()
+	if err != nil {
+		return fmt.Errorf("failed to get allowed namespace patterns: %w", err)
+	}
+
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	targetStage := stage.ForClusterTarget(target)
+
+	for _, pattern := range patterns {
+		rendered, err := util.RenderNamespaceName(targetStage, pattern)
+		if err != nil {
+			return fmt.Errorf("invalid allowed namespace pattern %q: %w", pattern, err)
+		}
+
+		re, err := regexp.Compile(rendered)
+		if err != nil {
+			return fmt.Errorf("invalid allowed namespace pattern %q: %w", pattern, err)
+		}
+
+		if re.MatchString(target.Namespace) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"namespace %s doesn't match any of the allowed patterns: %s",
+		target.Namespace,
+		strings.Join(patterns, ", "),
+	)
+}
@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
+)
+
+func Test_validateNamespacePolicy(t *testing.T) {
+	stage := &pipelineApi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pipeline-dev",
+			Namespace: "krci",
+		},
+		Spec: pipelineApi.StageSpec{
+			Name:        "dev",
+			CdPipeline:  "pipeline",
+			ClusterName: pipelineApi.InCluster,
+			Namespace:   "krci-pipeline-dev",
+		},
+	}
+
+	tests := []struct {
+		name            string
+		target          pipelineApi.ClusterTarget
+		allowedPatterns string
+		reserved        string
+		wantErr         require.ErrorAssertionFunc
+	}{
+		{
+			name:    "no policy configured",
+			target:  pipelineApi.ClusterTarget{Name: pipelineApi.InCluster, Namespace: "any-namespace"},
+			wantErr: require.NoError,
+		},
+		{
+			name:   "system namespace is reserved",
+			target: pipelineApi.ClusterTarget{Name: pipelineApi.InCluster, Namespace: "kube-system"},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "namespace kube-system is reserved")
+			},
+		},
+		{
+			name:   "operator namespace is reserved",
+			target: pipelineApi.ClusterTarget{Name: pipelineApi.InCluster, Namespace: "krci"},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "namespace krci is reserved")
+			},
+		},
+		{
+			name:     "configured namespace is reserved",
+			target:   pipelineApi.ClusterTarget{Name: "eu-cluster", Namespace: "argocd"},
+			reserved: "monitoring,argocd",
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "namespace argocd is reserved")
+			},
+		},
+		{
+			name:            "namespace matches pattern with stage fields",
+			target:          pipelineApi.ClusterTarget{Name: pipelineApi.InCluster, Namespace: "krci-pipeline-dev"},
+			allowedPatterns: `["^team-.+$", "^{{ .Tenant }}-{{ .CDPipeline }}-.+$"]`,
+			wantErr:         require.NoError,
+		},
+		{
+			name:            "namespace matches pattern with cluster name",
+			target:          pipelineApi.ClusterTarget{Name: "eu-cluster", Namespace: "dev-eu-cluster"},
+			allowedPatterns: `["^{{ .Stage }}-{{ .Cluster }}$"]`,
+			wantErr:         require.NoError,
+		},
+		{
+			name:            "namespace doesn't match patterns",
+			target:          pipelineApi.ClusterTarget{Name: pipelineApi.InCluster, Namespace: "my-namespace"},
+			allowedPatterns: `["^team-[a-z]{2,5}$", "^{{ .Tenant }}-.+$"]`,
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "namespace my-namespace doesn't match any of the allowed patterns")
+			},
+		},
+		{
+			name:            "invalid pattern",
+			target:          pipelineApi.ClusterTarget{Name: pipelineApi.InCluster, Namespace: "my-namespace"},
+			allowedPatterns: `["^team-(.+$"]`,
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid allowed namespace pattern")
+			},
+		},
+		{
+			name:            "invalid patterns list",
+			target:          pipelineApi.ClusterTarget{Name: pipelineApi.InCluster, Namespace: "my-namespace"},
+			allowedPatterns: "^team-.+$",
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to get allowed namespace patterns")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(platform.StageNamespaceAllowedPatterns, tt.allowedPatterns)
+			t.Setenv(platform.StageNamespaceReserved, tt.reserved)
+
+			tt.wantErr(t, validateNamespacePolicy(stage, tt.target))
+		})
+	}
+}
@@ -1,23 +1,43 @@
 package webhook
 
 import (
+	"context"
 	"errors"
 	"slices"
 	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
 )
 
+// +kubebuilder:rbac:groups="",namespace=placeholder,resources=events,verbs=create;patch
+
 const (
 	protectedLabel  = "app.edp.epam.com/edit-protection"
 	deleteOperation = "delete"
 	updateOperation = "update"
+
+	// protectionExpiresAtAnnotation contains RFC3339 time after which the edit-protection label is not enforced.
+	protectionExpiresAtAnnotation = "app.edp.epam.com/edit-protection-expires-at"
+
+	protectionBypassedReason = "EditProtectionBypassed"
 )
 
+// editProtection enforces the edit-protection label.
+// Members of the bypass groups can modify protected resources, every admitted bypass is recorded as an event.
+type editProtection struct {
+	recorder record.EventRecorder
+}
+
 func hasProtectedLabel(obj runtime.Object, operation string) bool {
 	o, ok := obj.(metaV1.Object)
 	if !ok {
@@ -28,6 +48,29 @@ func hasProtectedLabel(obj runtime.Object, operation string) bool {
 		slices.Contains(strings.Split(o.GetLabels()[protectedLabel], "-"), operation)
 }
 
+// isProtectionExpired checks if the edit-protection lock is expired.
+// Invalid expiry time is ignored, so the resource stays protected.
+func isProtectionExpired(ctx context.Context, obj runtime.Object) bool {
+	o, ok := obj.(metaV1.Object)
+	if !ok {
+		return false
+	}
+
+	val, ok := o.GetAnnotations()[protectionExpiresAtAnnotation]
+	if !ok {
+		return false
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Invalid edit-protection expiry time", "annotation", protectionExpiresAtAnnotation)
+
+		return false
+	}
+
+	return time.Now().After(expiresAt)
+}
+
 func isSpecUpdated(oldObj, newObj runtime.Object) bool {
 	switch old := oldObj.(type) {
 	case *pipelineApi.Stage:
@@ -43,18 +86,114 @@ func isSpecUpdated(oldObj, newObj runtime.Object) bool {
 	return false
 }
 
-func checkResourceProtectionFromModificationOnDelete(obj runtime.Object) error {
-	if hasProtectedLabel(obj, deleteOperation) {
-		return errors.New("resource contains label that protects it from deletion")
+// protectionBypass is a bypass of the edit protection.
+// It is recorded only after the request is admitted by all validators.
+type protectionBypass struct {
+	obj       runtime.Object
+	operation string
+	username  string
+	dryRun    bool
+}
+
+func (p *editProtection) checkOnDelete(ctx context.Context, obj runtime.Object) (*protectionBypass, error) {
+	if !hasProtectedLabel(obj, deleteOperation) || isProtectionExpired(ctx, obj) {
+		return nil, nil
+	}
+
+	if bypass := p.bypass(ctx, obj, deleteOperation); bypass != nil {
+		return bypass, nil
+	}
+
+	return nil, errors.New("resource contains label that protects it from deletion")
+}
+
+// checkOnUpdate enforces the protection if either the current or the new resource state has the protected label.
+// The lock expiry is taken from the current state, so the lock can't be lifted in the same request.
+// The expiry time of an active lock can be changed only by members of the bypass groups.
+func (p *editProtection) checkOnUpdate(
+	ctx context.Context,
+	oldObj, newObj runtime.Object,
+) (*protectionBypass, error) {
+	locked := (hasProtectedLabel(oldObj, updateOperation) || hasProtectedLabel(oldObj, deleteOperation)) &&
+		!isProtectionExpired(ctx, oldObj)
+
+	if locked && isProtectionExpiryUpdated(oldObj, newObj) {
+		if bypass := p.bypass(ctx, newObj, updateOperation); bypass != nil {
+			return bypass, nil
+		}
+
+		return nil, errors.New("edit-protection expiry time can be changed only by members of the bypass groups")
+	}
+
+	protected := hasProtectedLabel(oldObj, updateOperation) || hasProtectedLabel(newObj, updateOperation)
+
+	if !protected || !isSpecUpdated(oldObj, newObj) || isProtectionExpired(ctx, oldObj) {
+		return nil, nil
+	}
+
+	if bypass := p.bypass(ctx, newObj, updateOperation); bypass != nil {
+		return bypass, nil
+	}
+
+	return nil, errors.New("resource contains label that protects it from modification")
+}
+
+func isProtectionExpiryUpdated(oldObj, newObj runtime.Object) bool {
+	oldMeta, ok := oldObj.(metaV1.Object)
+	if !ok {
+		return false
 	}
 
-	return nil
+	newMeta, ok := newObj.(metaV1.Object)
+	if !ok {
+		return false
+	}
+
+	oldVal, oldOk := oldMeta.GetAnnotations()[protectionExpiresAtAnnotation]
+	newVal, newOk := newMeta.GetAnnotations()[protectionExpiresAtAnnotation]
+
+	return oldOk != newOk || oldVal != newVal
+}
+
+// bypass checks if the request user belongs to one of the bypass groups.
+// It returns nil if the protection can't be bypassed.
+func (p *editProtection) bypass(ctx context.Context, obj runtime.Object, operation string) *protectionBypass {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+
+	groups := platform.GetEditProtectionBypassGroups()
+
+	if !slices.ContainsFunc(req.UserInfo.Groups, func(group string) bool {
+		return slices.Contains(groups, group)
+	}) {
+		return nil
+	}
+
+	return &protectionBypass{
+		obj:       obj,
+		operation: operation,
+		username:  req.UserInfo.Username,
+		dryRun:    req.DryRun != nil && *req.DryRun,
+	}
 }
 
-func checkResourceProtectionFromModificationOnUpdate(oldObj, newObj runtime.Object) error {
-	if hasProtectedLabel(newObj, updateOperation) && isSpecUpdated(oldObj, newObj) {
-		return errors.New("resource contains label that protects it from modification")
+// recordBypass records the admitted bypass as an event.
+// Dry-run requests are not recorded, because the webhook declares no side effects on dry run.
+func (p *editProtection) recordBypass(ctx context.Context, bypass *protectionBypass) {
+	if bypass == nil || bypass.dryRun {
+		return
 	}
 
-	return nil
+	ctrl.LoggerFrom(ctx).Info("Edit protection has been bypassed", "user", bypass.username, "operation", bypass.operation)
+
+	p.recorder.Eventf(
+		bypass.obj,
+		corev1.EventTypeWarning,
+		protectionBypassedReason,
+		"User %s bypassed edit protection on %s",
+		bypass.username,
+		bypass.operation,
+	)
 }
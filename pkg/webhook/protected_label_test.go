@@ -0,0 +1,323 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
+)
+
+func Test_editProtection(t *testing.T) {
+	newStage := func(description string, annotations map[string]string) *pipelineApi.Stage {
+		return &pipelineApi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "stage",
+				Namespace:   "default",
+				Labels:      map[string]string{protectedLabel: "update-delete"},
+				Annotations: annotations,
+			},
+			Spec: pipelineApi.StageSpec{
+				Description: description,
+			},
+		}
+	}
+
+	requestContext := func(groups ...string) context.Context {
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{
+					Username: "john",
+					Groups:   groups,
+				},
+			},
+		})
+	}
+
+	dryRunContext := func(groups ...string) context.Context {
+		dryRun := true
+
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{
+					Username: "john",
+					Groups:   groups,
+				},
+				DryRun: &dryRun,
+			},
+		})
+	}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		annotations map[string]string
+		wantErr     require.ErrorAssertionFunc
+		wantEvent   bool
+	}{
+		{
+			name:    "resource is protected",
+			ctx:     requestContext("developers"),
+			wantErr: require.Error,
+		},
+		{
+			name:    "resource is protected without admission request",
+			ctx:     context.Background(),
+			wantErr: require.Error,
+		},
+		{
+			name:      "admin group bypasses protection",
+			ctx:       requestContext("developers", "oidc-admins"),
+			wantErr:   require.NoError,
+			wantEvent: true,
+		},
+		{
+			name:      "configured group bypasses protection",
+			ctx:       requestContext("sre"),
+			wantErr:   require.NoError,
+			wantEvent: true,
+		},
+		{
+			name:    "dry run bypass is not recorded",
+			ctx:     dryRunContext("oidc-admins"),
+			wantErr: require.NoError,
+		},
+		{
+			name: "expired protection is not enforced",
+			ctx:  requestContext("developers"),
+			annotations: map[string]string{
+				protectionExpiresAtAnnotation: time.Now().Add(-time.Hour).Format(time.RFC3339),
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "protection is not expired",
+			ctx:  requestContext("developers"),
+			annotations: map[string]string{
+				protectionExpiresAtAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+			},
+			wantErr: require.Error,
+		},
+		{
+			name: "invalid expiry time keeps protection",
+			ctx:  requestContext("developers"),
+			annotations: map[string]string{
+				protectionExpiresAtAnnotation: "tomorrow",
+			},
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(platform.OIDCAdminGroupName, "oidc-admins")
+			t.Setenv(platform.EditProtectionBypassGroups, "sre")
+
+			recorder := record.NewFakeRecorder(10)
+			p := editProtection{recorder: recorder}
+
+			oldStage := newStage("stage", tt.annotations)
+			newStage := newStage("stage 2", tt.annotations)
+
+			updateBypass, err := p.checkOnUpdate(tt.ctx, oldStage, newStage)
+			tt.wantErr(t, err)
+			p.recordBypass(tt.ctx, updateBypass)
+
+			deleteBypass, err := p.checkOnDelete(tt.ctx, oldStage)
+			tt.wantErr(t, err)
+			p.recordBypass(tt.ctx, deleteBypass)
+
+			if tt.wantEvent {
+				require.Len(t, recorder.Events, 2)
+				assert.Contains(t, <-recorder.Events, "User john bypassed edit protection on update")
+				assert.Contains(t, <-recorder.Events, "User john bypassed edit protection on delete")
+
+				return
+			}
+
+			assert.Empty(t, recorder.Events)
+		})
+	}
+}
+
+func TestStageValidationWebhook_ValidateUpdate_protectionBypass(t *testing.T) {
+	t.Setenv(platform.OIDCAdminGroupName, "oidc-admins")
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, pipelineApi.AddToScheme(scheme))
+
+	newStage := func(pipeline, description string) *pipelineApi.Stage {
+		return &pipelineApi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "stage",
+				Namespace: "default",
+				Labels:    map[string]string{protectedLabel: updateOperation},
+			},
+			Spec: pipelineApi.StageSpec{
+				Name:        "dev",
+				CdPipeline:  pipeline,
+				Description: description,
+			},
+		}
+	}
+
+	ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{
+				Username: "john",
+				Groups:   []string{"oidc-admins"},
+			},
+		},
+	})
+
+	tests := []struct {
+		name      string
+		newStage  *pipelineApi.Stage
+		wantErr   require.ErrorAssertionFunc
+		wantEvent bool
+	}{
+		{
+			name:      "admitted bypass is recorded",
+			newStage:  newStage("pipeline", "stage 2"),
+			wantErr:   require.NoError,
+			wantEvent: true,
+		},
+		{
+			name:     "rejected bypass is not recorded",
+			newStage: newStage("other-pipeline", "stage 2"),
+			wantErr:  require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			w := NewStageValidationWebhook(fake.NewClientBuilder().WithScheme(scheme).Build(), recorder)
+
+			_, err := w.ValidateUpdate(ctx, newStage("pipeline", "stage"), tt.newStage)
+
+			tt.wantErr(t, err)
+
+			if tt.wantEvent {
+				require.Len(t, recorder.Events, 1)
+				assert.Contains(t, <-recorder.Events, "User john bypassed edit protection on update")
+
+				return
+			}
+
+			assert.Empty(t, recorder.Events)
+		})
+	}
+}
+
+func Test_editProtection_checkOnUpdate_expiry(t *testing.T) {
+	t.Setenv(platform.OIDCAdminGroupName, "oidc-admins")
+
+	newStage := func(labels, annotations map[string]string, description string) *pipelineApi.Stage {
+		return &pipelineApi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "stage",
+				Namespace:   "default",
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Spec: pipelineApi.StageSpec{
+				Description: description,
+			},
+		}
+	}
+
+	requestContext := func(groups ...string) context.Context {
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{
+					Username: "john",
+					Groups:   groups,
+				},
+			},
+		})
+	}
+
+	protected := map[string]string{protectedLabel: "update-delete"}
+	expired := map[string]string{
+		protectionExpiresAtAnnotation: time.Now().Add(-time.Hour).Format(time.RFC3339),
+	}
+	active := map[string]string{
+		protectionExpiresAtAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		oldStage *pipelineApi.Stage
+		newStage *pipelineApi.Stage
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			name:     "expired time set together with spec change is denied",
+			ctx:      requestContext("developers"),
+			oldStage: newStage(protected, nil, "stage"),
+			newStage: newStage(protected, expired, "stage 2"),
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "expiry time can be changed only by members of the bypass groups")
+			},
+		},
+		{
+			name:     "expiry time change is denied",
+			ctx:      requestContext("developers"),
+			oldStage: newStage(protected, active, "stage"),
+			newStage: newStage(protected, expired, "stage"),
+			wantErr:  require.Error,
+		},
+		{
+			name:     "protected label removed together with spec change is denied",
+			ctx:      requestContext("developers"),
+			oldStage: newStage(protected, nil, "stage"),
+			newStage: newStage(nil, nil, "stage 2"),
+			wantErr:  require.Error,
+		},
+		{
+			name:     "admin changes expiry time",
+			ctx:      requestContext("oidc-admins"),
+			oldStage: newStage(protected, nil, "stage"),
+			newStage: newStage(protected, expired, "stage 2"),
+			wantErr:  require.NoError,
+		},
+		{
+			name:     "expired lock is renewed",
+			ctx:      requestContext("developers"),
+			oldStage: newStage(protected, expired, "stage"),
+			newStage: newStage(protected, active, "stage"),
+			wantErr:  require.NoError,
+		},
+		{
+			name:     "lock is added to unprotected resource",
+			ctx:      requestContext("developers"),
+			oldStage: newStage(nil, nil, "stage"),
+			newStage: newStage(protected, active, "stage"),
+			wantErr:  require.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := editProtection{recorder: record.NewFakeRecorder(10)}
+
+			_, err := p.checkOnUpdate(tt.ctx, tt.oldStage, tt.newStage)
+
+			tt.wantErr(t, err)
+		})
+	}
+}
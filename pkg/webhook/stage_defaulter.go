@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/objectmodifier"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
+)
+
+// +kubebuilder:webhook:path=/mutate-v2-edp-epam-com-v1-stage,mutating=true,failurePolicy=fail,sideEffects=None,groups=v2.edp.epam.com,resources=stages,verbs=create;update,versions=v1,name=mstage.epam.com,admissionReviewVersions=v1
+
+// StageDefaulter is a webhook for setting default values to Stage CRD.
+type StageDefaulter struct {
+	modifiers []objectmodifier.StageModifier
+}
+
+// NewStageDefaulter creates a new webhook for setting default values to Stage CR.
+func NewStageDefaulter(k8sClient client.Client, scheme *runtime.Scheme) *StageDefaulter {
+	return &StageDefaulter{modifiers: objectmodifier.NewStageModifiers(k8sClient, scheme)}
+}
+
+// SetupWebhookWithManager sets up the defaulting webhook with the manager for Stage CR.
+func (r *StageDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(&pipelineApi.Stage{}).
+		WithDefaulter(r).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("failed to build Stage defaulting webhook: %w", err)
+	}
+
+	return nil
+}
+
+var _ webhook.CustomDefaulter = &StageDefaulter{}
+
+// Default sets the CDPipeline label, the CDPipeline owner reference, the target namespace
+// and the user annotations to the Stage.
+func (r *StageDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	stage, ok := obj.(*pipelineApi.Stage)
+	if !ok {
+		return errors.New("the wrong object given, expected Stage")
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithValues("stage", stage.Name)
+
+	if err := setUserAnnotations(ctx, stage, &pipelineApi.Stage{}); err != nil {
+		return fmt.Errorf("failed to set user annotations: %w", err)
+	}
+
+	if stage.Spec.Namespace == "" {
+		ns, err := util.RenderNamespaceName(stage, platform.GetStageNamespaceTemplate())
+		if err != nil {
+			return fmt.Errorf("failed to set default namespace: %w", err)
+		}
+
+		stage.Spec.Namespace = ns
+	}
+
+	for _, modifier := range r.modifiers {
+		if _, err := modifier.Apply(ctrl.LoggerInto(ctx, log), stage); err != nil {
+			// CDPipeline can be created after the Stage, the controller sets the owner reference later.
+			if k8sErrors.IsNotFound(err) {
+				log.Info("Skip Stage default value", "reason", err.Error())
+
+				continue
+			}
+
+			return fmt.Errorf("failed to set Stage default values: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+func TestStageDefaulter_Default(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, pipelineApi.AddToScheme(scheme))
+
+	pipeline := &pipelineApi.CDPipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pipeline",
+			Namespace: "default",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		obj     runtime.Object
+		objects []client.Object
+		wantErr require.ErrorAssertionFunc
+		want    func(t *testing.T, stage *pipelineApi.Stage)
+	}{
+		{
+			name: "should set default namespace, label and owner reference",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipeline-dev",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:       "dev",
+					CdPipeline: "pipeline",
+				},
+			},
+			objects: []client.Object{pipeline},
+			wantErr: require.NoError,
+			want: func(t *testing.T, stage *pipelineApi.Stage) {
+				assert.Equal(t, "default-pipeline-dev", stage.Spec.Namespace)
+				assert.Equal(t, "pipeline", stage.Labels[pipelineApi.StageCdPipelineLabelName])
+				require.Len(t, stage.OwnerReferences, 1)
+				assert.Equal(t, "pipeline", stage.OwnerReferences[0].Name)
+			},
+		},
+		{
+			name: "should keep namespace if it is set",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipeline-dev",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:       "dev",
+					CdPipeline: "pipeline",
+					Namespace:  "custom-ns",
+				},
+			},
+			objects: []client.Object{pipeline},
+			wantErr: require.NoError,
+			want: func(t *testing.T, stage *pipelineApi.Stage) {
+				assert.Equal(t, "custom-ns", stage.Spec.Namespace)
+			},
+		},
+		{
+			name: "should skip owner reference if CDPipeline doesn't exist",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipeline-dev",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:       "dev",
+					CdPipeline: "pipeline",
+				},
+			},
+			wantErr: require.NoError,
+			want: func(t *testing.T, stage *pipelineApi.Stage) {
+				assert.Equal(t, "default-pipeline-dev", stage.Spec.Namespace)
+				assert.Equal(t, "pipeline", stage.Labels[pipelineApi.StageCdPipelineLabelName])
+				assert.Empty(t, stage.OwnerReferences)
+			},
+		},
+		{
+			name: "should return error if object is not Stage",
+			obj:  &pipelineApi.CDPipeline{},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "the wrong object given, expected Stage")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+
+			err := NewStageDefaulter(k8sClient, scheme).Default(
+				ctrl.LoggerInto(context.Background(), logr.Discard()),
+				tt.obj,
+			)
+
+			tt.wantErr(t, err)
+
+			if tt.want != nil {
+				stage, ok := tt.obj.(*pipelineApi.Stage)
+				require.True(t, ok)
+
+				tt.want(t, stage)
+			}
+		})
+	}
+}
@@ -4,9 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	rbacApi "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -14,21 +21,39 @@ import (
 
 	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
 	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/multiclusterclient"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
 )
 
 const listLimit = 1000
 
-// +kubebuilder:webhook:path=/validate-v2-edp-epam-com-v1-stage,mutating=false,failurePolicy=fail,sideEffects=None,groups=v2.edp.epam.com,resources=stages,verbs=create;update;delete,versions=v1,name=stage.epam.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-v2-edp-epam-com-v1-stage,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=v2.edp.epam.com,resources=stages,verbs=create;update;delete,versions=v1,name=stage.epam.com,admissionReviewVersions=v1
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
+// clusterClientProvider provides clients for the clusters where stages are deployed.
+type clusterClientProvider interface {
+	GetClusterClient(
+		ctx context.Context,
+		secretNamespace string,
+		clusterName string,
+		options client.Options,
+	) (client.Client, error)
+}
+
 // StageValidationWebhook is a webhook for validating Stage CRD.
 type StageValidationWebhook struct {
-	client client.Client
+	client         client.Client
+	clientProvider clusterClientProvider
+	protection     editProtection
 }
 
 // NewStageValidationWebhook creates a new webhook for validating Stage CR.
-func NewStageValidationWebhook(k8sClient client.Client) *StageValidationWebhook {
-	return &StageValidationWebhook{client: k8sClient}
+func NewStageValidationWebhook(k8sClient client.Client, recorder record.EventRecorder) *StageValidationWebhook {
+	return &StageValidationWebhook{
+		client:         k8sClient,
+		clientProvider: multiclusterclient.NewClientProvider(k8sClient),
+		protection:     editProtection{recorder: recorder},
+	}
 }
 
 // SetupWebhookWithManager sets up the webhook with the manager for Stage CR.
@@ -53,24 +78,129 @@ func (r *StageValidationWebhook) ValidateCreate(ctx context.Context, obj runtime
 		return nil, errors.New("the wrong object given, expected Stage")
 	}
 
+	if err := uniqueClusterTargets(createdStage); err != nil {
+		return nil, err
+	}
+
+	if err := validateAccessControl(createdStage); err != nil {
+		return nil, err
+	}
+
+	for _, target := range createdStage.GetClusterTargets() {
+		if err := validateNamespacePolicy(createdStage, target); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := r.uniqueTargetNamespaces(ctx, createdStage); err != nil {
 		return nil, err
 	}
 
-	return nil, r.uniqueTargetNamespaceAcrossCluster(ctx, createdStage)
+	for _, target := range createdStage.GetClusterTargets() {
+		if err := r.validateClusterTarget(ctx, createdStage, target); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.validateStageOrder(ctx, createdStage)
 }
 
 // ValidateUpdate is a webhook for validating the updating of the Stage CR.
-func (*StageValidationWebhook) ValidateUpdate(
-	_ context.Context,
+// Fields that identify the Stage target can't be changed, because the old target would be orphaned.
+// Only new clusters can be added to the Stage.
+func (r *StageValidationWebhook) ValidateUpdate(
+	ctx context.Context,
 	oldObj, newObj runtime.Object,
 ) (admission.Warnings, error) {
-	return nil, checkResourceProtectionFromModificationOnUpdate(oldObj, newObj)
+	bypass, err := r.protection.checkOnUpdate(ctx, oldObj, newObj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldStage, ok := oldObj.(*pipelineApi.Stage)
+	if !ok {
+		return nil, errors.New("the wrong object given, expected Stage")
+	}
+
+	newStage, ok := newObj.(*pipelineApi.Stage)
+	if !ok {
+		return nil, errors.New("the wrong object given, expected Stage")
+	}
+
+	if err = r.validateUpdate(ctx, oldStage, newStage); err != nil {
+		return nil, err
+	}
+
+	r.protection.recordBypass(ctx, bypass)
+
+	return nil, nil
+}
+
+func (r *StageValidationWebhook) validateUpdate(ctx context.Context, oldStage, newStage *pipelineApi.Stage) error {
+	if err := checkImmutableFields(oldStage, newStage); err != nil {
+		return err
+	}
+
+	if oldStage.Spec.Order != newStage.Spec.Order {
+		if err := r.validateStageOrderChange(ctx, newStage); err != nil {
+			return err
+		}
+	}
+
+	if !equality.Semantic.DeepEqual(oldStage.Spec.AccessControl, newStage.Spec.AccessControl) {
+		if err := validateAccessControl(newStage); err != nil {
+			return err
+		}
+	}
+
+	addedTargets := getAddedClusterTargets(oldStage, newStage)
+	if len(addedTargets) == 0 {
+		return nil
+	}
+
+	if err := uniqueClusterTargets(newStage); err != nil {
+		return err
+	}
+
+	for _, target := range addedTargets {
+		if err := validateNamespacePolicy(newStage, target); err != nil {
+			return err
+		}
+	}
+
+	if err := r.uniqueTargetNamespaces(ctx, newStage); err != nil {
+		return err
+	}
+
+	for _, target := range addedTargets {
+		if err := r.validateClusterTarget(ctx, newStage, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // ValidateDelete is a webhook for validating the deleting of the Stage CR.
-func (*StageValidationWebhook) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return nil, checkResourceProtectionFromModificationOnDelete(obj)
+// A Stage can't be deleted while it is followed by other Stages, because it would leave a gap in the Stage order.
+func (r *StageValidationWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	bypass, err := r.protection.checkOnDelete(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	deletedStage, ok := obj.(*pipelineApi.Stage)
+	if !ok {
+		return nil, errors.New("the wrong object given, expected Stage")
+	}
+
+	if err = r.validateStageOrderOnDelete(ctx, deletedStage); err != nil {
+		return nil, err
+	}
+
+	r.protection.recordBypass(ctx, bypass)
+
+	return nil, nil
 }
 
 func (r *StageValidationWebhook) uniqueTargetNamespaces(ctx context.Context, stage *pipelineApi.Stage) error {
@@ -85,17 +215,120 @@ func (r *StageValidationWebhook) uniqueTargetNamespaces(ctx context.Context, sta
 		return fmt.Errorf("failed to list stages: %w", err)
 	}
 
+	targets := stage.GetClusterTargets()
+
 	for i := range stages.Items {
-		if stages.Items[i].Name == stage.Name || stages.Items[i].Spec.ClusterName != stage.Spec.ClusterName {
+		if stages.Items[i].Name == stage.Name {
 			continue
 		}
 
-		if stages.Items[i].Spec.Namespace == stage.Spec.Namespace {
+		for _, existing := range stages.Items[i].GetClusterTargets() {
+			for _, target := range targets {
+				if clusterNameOrDefault(existing.Name) != clusterNameOrDefault(target.Name) ||
+					existing.Namespace != target.Namespace {
+					continue
+				}
+
+				return fmt.Errorf(
+					"namespace %s is already used in CDPipeline %s Stage %s",
+					target.Namespace,
+					stages.Items[i].Spec.CdPipeline,
+					stages.Items[i].Name,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateStageOrder checks that the Stage CDPipeline exists
+// and the Stage order is unique across the CDPipeline Stages.
+// Stages can be applied in any order, e.g. by GitOps tools, so a gap in the order is reported as a warning.
+// The check is skipped if the CDPipeline Stages are being reordered.
+func (r *StageValidationWebhook) validateStageOrder(
+	ctx context.Context,
+	stage *pipelineApi.Stage,
+) (admission.Warnings, error) {
+	pipeline, err := r.getStageCDPipeline(ctx, stage)
+	if err != nil {
+		return nil, err
+	}
+
+	if isStageReorderAllowed(pipeline) {
+		return nil, nil
+	}
+
+	stages, err := r.listPipelineStages(ctx, stage)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := []int{stage.Spec.Order}
+
+	for i := range stages {
+		if stages[i].Spec.Order == stage.Spec.Order {
+			return nil, fmt.Errorf(
+				"order %d is already used by Stage %s in CDPipeline %s",
+				stage.Spec.Order,
+				stages[i].Name,
+				stage.Spec.CdPipeline,
+			)
+		}
+
+		orders = append(orders, stages[i].Spec.Order)
+	}
+
+	slices.Sort(orders)
+
+	for i, order := range orders {
+		if order != i {
+			return admission.Warnings{fmt.Sprintf(
+				"stages of CDPipeline %s must have contiguous order starting from 0, order %d is missing, "+
+					"Stage %s won't be processed until the missing Stage is created",
+				stage.Spec.CdPipeline,
+				i,
+				stage.Name,
+			)}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// validateStageOrderOnDelete checks that the deleted Stage is the last one in the CDPipeline.
+// The check is skipped if the CDPipeline is being deleted or its Stages are being reordered.
+func (r *StageValidationWebhook) validateStageOrderOnDelete(ctx context.Context, stage *pipelineApi.Stage) error {
+	pipeline := &pipelineApi.CDPipeline{}
+
+	if err := r.client.Get(ctx, client.ObjectKey{
+		Namespace: stage.Namespace,
+		Name:      stage.Spec.CdPipeline,
+	}, pipeline); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get cdpipeline %s: %w", stage.Spec.CdPipeline, err)
+	}
+
+	if !pipeline.GetDeletionTimestamp().IsZero() || isStageReorderAllowed(pipeline) {
+		return nil
+	}
+
+	stages, err := r.listPipelineStages(ctx, stage)
+	if err != nil {
+		return err
+	}
+
+	for i := range stages {
+		if stages[i].Spec.Order > stage.Spec.Order {
 			return fmt.Errorf(
-				"namespace %s is already used in CDPipeline %s Stage %s",
-				stage.Spec.Namespace,
-				stages.Items[i].Spec.CdPipeline,
-				stages.Items[i].Name,
+				"stage %s can't be deleted, because it is followed by Stage %s in CDPipeline %s, "+
+					"delete the following Stages first or reorder the Stages",
+				stage.Name,
+				stages[i].Name,
+				stage.Spec.CdPipeline,
 			)
 		}
 	}
@@ -103,24 +336,281 @@ func (r *StageValidationWebhook) uniqueTargetNamespaces(ctx context.Context, sta
 	return nil
 }
 
-func (r *StageValidationWebhook) uniqueTargetNamespaceAcrossCluster(
+// listPipelineStages returns the Stages of the same CDPipeline except the given one.
+// Stages that are being deleted are skipped.
+func (r *StageValidationWebhook) listPipelineStages(
 	ctx context.Context,
 	stage *pipelineApi.Stage,
+) ([]pipelineApi.Stage, error) {
+	stages := &pipelineApi.StageList{}
+
+	if err := r.client.List(
+		ctx,
+		stages,
+		client.InNamespace(stage.Namespace),
+		client.Limit(listLimit),
+	); err != nil {
+		return nil, fmt.Errorf("failed to list stages: %w", err)
+	}
+
+	result := make([]pipelineApi.Stage, 0, len(stages.Items))
+
+	for i := range stages.Items {
+		if stages.Items[i].Name == stage.Name ||
+			stages.Items[i].Spec.CdPipeline != stage.Spec.CdPipeline ||
+			!stages.Items[i].GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		result = append(result, stages.Items[i])
+	}
+
+	return result, nil
+}
+
+// validateStageOrderChange checks that the Stage order is changed during the CDPipeline Stages reordering.
+func (r *StageValidationWebhook) validateStageOrderChange(ctx context.Context, stage *pipelineApi.Stage) error {
+	pipeline, err := r.getStageCDPipeline(ctx, stage)
+	if err != nil {
+		return err
+	}
+
+	if !isStageReorderAllowed(pipeline) {
+		return fmt.Errorf(
+			"spec.order can be changed only if CDPipeline %s has annotation %s=true",
+			pipeline.Name,
+			pipelineApi.StageReorderAnnotation,
+		)
+	}
+
+	return nil
+}
+
+func (r *StageValidationWebhook) getStageCDPipeline(
+	ctx context.Context,
+	stage *pipelineApi.Stage,
+) (*pipelineApi.CDPipeline, error) {
+	pipeline := &pipelineApi.CDPipeline{}
+
+	if err := r.client.Get(ctx, client.ObjectKey{
+		Namespace: stage.Namespace,
+		Name:      stage.Spec.CdPipeline,
+	}, pipeline); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, fmt.Errorf("cdpipeline %s doesn't exist", stage.Spec.CdPipeline)
+		}
+
+		return nil, fmt.Errorf("failed to get cdpipeline %s: %w", stage.Spec.CdPipeline, err)
+	}
+
+	return pipeline, nil
+}
+
+func isStageReorderAllowed(pipeline *pipelineApi.CDPipeline) bool {
+	return pipeline.GetAnnotations()[pipelineApi.StageReorderAnnotation] == "true"
+}
+
+// checkImmutableFields checks that fields which identify the Stage target are not changed.
+func checkImmutableFields(oldStage, newStage *pipelineApi.Stage) error {
+	immutableFields := []struct {
+		path     string
+		oldValue string
+		newValue string
+	}{
+		{path: "spec.name", oldValue: oldStage.Spec.Name, newValue: newStage.Spec.Name},
+		{path: "spec.cdPipeline", oldValue: oldStage.Spec.CdPipeline, newValue: newStage.Spec.CdPipeline},
+		{path: "spec.namespace", oldValue: oldStage.Spec.Namespace, newValue: newStage.Spec.Namespace},
+		{
+			path:     "spec.clusterName",
+			oldValue: clusterNameOrDefault(oldStage.Spec.ClusterName),
+			newValue: clusterNameOrDefault(newStage.Spec.ClusterName),
+		},
+	}
+
+	for _, f := range immutableFields {
+		if f.oldValue != f.newValue {
+			return fmt.Errorf("%s is immutable, change from %q to %q is not allowed", f.path, f.oldValue, f.newValue)
+		}
+	}
+
+	newTargets := make(map[string]pipelineApi.ClusterTarget, len(newStage.Spec.Clusters))
+	for _, target := range newStage.Spec.Clusters {
+		newTargets[target.Name] = target
+	}
+
+	for _, oldTarget := range oldStage.Spec.Clusters {
+		newTarget, ok := newTargets[oldTarget.Name]
+		if !ok {
+			return fmt.Errorf("cluster %s can't be removed from spec.clusters", oldTarget.Name)
+		}
+
+		if newTarget.Namespace != oldTarget.Namespace {
+			return fmt.Errorf("namespace of cluster %s in spec.clusters is immutable", oldTarget.Name)
+		}
+	}
+
+	return nil
+}
+
+// getAddedClusterTargets returns cluster targets that are present only in the new Stage.
+func getAddedClusterTargets(oldStage, newStage *pipelineApi.Stage) []pipelineApi.ClusterTarget {
+	oldTargets := make(map[string]struct{}, len(oldStage.Spec.Clusters))
+	for _, target := range oldStage.Spec.Clusters {
+		oldTargets[target.Name] = struct{}{}
+	}
+
+	var added []pipelineApi.ClusterTarget
+
+	for _, target := range newStage.GetClusterTargets()[1:] {
+		if _, ok := oldTargets[target.Name]; !ok {
+			added = append(added, target)
+		}
+	}
+
+	return added
+}
+
+func clusterNameOrDefault(clusterName string) string {
+	if clusterName == "" {
+		return pipelineApi.InCluster
+	}
+
+	return clusterName
+}
+
+// validateAccessControl checks that the access control bindings use the roles allowed in the operator configuration
+// and bind only ServiceAccounts of the Stage namespace. The bindings are rejected for HNC subnamespaces.
+func validateAccessControl(stage *pipelineApi.Stage) error {
+	if len(stage.Spec.AccessControl) == 0 {
+		return nil
+	}
+
+	// HNC propagates the tenant RoleBindings from the parent namespace, so the access can't be restricted.
+	if platform.ManageNamespace() && platform.IsKubernetes() && platform.HNCEnabled() && stage.InCluster() {
+		return errors.New("accessControl is not supported with the HNC tenancy engine, " +
+			"RoleBindings of the tenant are propagated to the Stage namespace from the parent namespace")
+	}
+
+	allowedRoles := platform.GetAccessControlAllowedRoles()
+
+	for _, binding := range stage.Spec.AccessControl {
+		if !slices.Contains(allowedRoles, binding.RoleRef.Name) {
+			return fmt.Errorf(
+				"role %s of access control binding %s is not allowed, allowed roles: %s",
+				binding.RoleRef.Name, binding.Name, strings.Join(allowedRoles, ", "),
+			)
+		}
+
+		for _, subject := range binding.Subjects {
+			if subject.Kind == rbacApi.ServiceAccountKind && subject.Namespace != "" &&
+				subject.Namespace != stage.Spec.Namespace {
+				return fmt.Errorf(
+					"ServiceAccount %s/%s of access control binding %s is not in the Stage namespace %s",
+					subject.Namespace, subject.Name, binding.Name, stage.Spec.Namespace,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// uniqueClusterTargets checks that the stage is deployed to each cluster only once.
+func uniqueClusterTargets(stage *pipelineApi.Stage) error {
+	clusters := make(map[string]struct{}, len(stage.Spec.Clusters)+1)
+
+	for _, target := range stage.GetClusterTargets() {
+		// An empty spec.clusterName is the same cluster as in-cluster.
+		name := clusterNameOrDefault(target.Name)
+
+		if _, ok := clusters[name]; ok {
+			return fmt.Errorf("cluster %s is specified more than once", name)
+		}
+
+		clusters[name] = struct{}{}
+	}
+
+	return nil
+}
+
+// validateClusterTarget checks that the cluster is available
+// and the target namespace is not used in the cluster.
+func (r *StageValidationWebhook) validateClusterTarget(
+	ctx context.Context,
+	stage *pipelineApi.Stage,
+	target pipelineApi.ClusterTarget,
+) error {
+	if err := r.checkClusterSecret(ctx, stage.Namespace, target.Name); err != nil {
+		return err
+	}
+
+	clusterClient, err := r.clientProvider.GetClusterClient(ctx, stage.Namespace, target.Name, client.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to get cluster %s client: %w", target.Name, err)
+	}
+
+	return uniqueTargetNamespaceAcrossCluster(ctx, clusterClient, target)
+}
+
+// checkClusterSecret checks that the cluster secret exists and the cluster is connected.
+func (r *StageValidationWebhook) checkClusterSecret(ctx context.Context, secretNamespace, clusterName string) error {
+	if clusterName == "" || clusterName == pipelineApi.InCluster {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, client.ObjectKey{
+		Namespace: secretNamespace,
+		Name:      clusterName,
+	}, secret); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("cluster %s is not found, secret %s doesn't exist", clusterName, clusterName)
+		}
+
+		return fmt.Errorf("failed to get cluster secret %s: %w", clusterName, err)
+	}
+
+	// The kubeconfig secret of the IRSA cluster is generated from the <name>-cluster secret,
+	// which holds the cluster label and the connection status.
+	if owner := metav1.GetControllerOfNoCopy(secret); owner != nil && owner.Kind == "Secret" &&
+		secret.GetLabels()[multiclusterclient.ClusterSecretTypeLabel] != multiclusterclient.ClusterSecretTypeCluster {
+		ownerSecret := &corev1.Secret{}
+		if err := r.client.Get(ctx, client.ObjectKey{
+			Namespace: secretNamespace,
+			Name:      owner.Name,
+		}, ownerSecret); err != nil {
+			return fmt.Errorf("failed to get cluster secret %s: %w", owner.Name, err)
+		}
+
+		secret = ownerSecret
+	}
+
+	if err := multiclusterclient.CheckClusterSecret(secret); err != nil {
+		return fmt.Errorf("cluster %s is not available: %w", clusterName, err)
+	}
+
+	return nil
+}
+
+func uniqueTargetNamespaceAcrossCluster(
+	ctx context.Context,
+	clusterClient client.Client,
+	target pipelineApi.ClusterTarget,
 ) error {
 	namespaces := &corev1.NamespaceList{}
-	if err := r.client.List(
+	if err := clusterClient.List(
 		ctx,
 		namespaces,
 		client.MatchingLabels{
-			util.TenantLabelName: stage.Spec.Namespace,
+			util.TenantLabelName: target.Namespace,
 		},
 	); err != nil {
-		return fmt.Errorf("failed to list namespaces: %w", err)
+		return fmt.Errorf("failed to list namespaces in cluster %s: %w", target.Name, err)
 	}
 
 	for i := range namespaces.Items {
-		if namespaces.Items[i].Name == stage.Spec.Namespace {
-			return fmt.Errorf("namespace %s is already used in the cluster", stage.Spec.Namespace)
+		if namespaces.Items[i].Name == target.Namespace {
+			return fmt.Errorf("namespace %s is already used in the cluster %s", target.Namespace, target.Name)
 		}
 	}
 
@@ -4,19 +4,25 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 
 	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
 	"github.com/epam/edp-cd-pipeline-operator/v2/internal/controller/stage/chain/util"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/multiclusterclient"
+	"github.com/epam/edp-cd-pipeline-operator/v2/pkg/platform"
 )
 
 func TestStageValidationWebhook_ValidateCreate(t *testing.T) {
@@ -26,11 +32,36 @@ func TestStageValidationWebhook_ValidateCreate(t *testing.T) {
 	require.NoError(t, pipelineApi.AddToScheme(scheme))
 	require.NoError(t, corev1.AddToScheme(scheme))
 
+	pipeline := &pipelineApi.CDPipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pipeline",
+			Namespace: "default",
+		},
+	}
+
+	newOrderedStage := func(name string, order int) *pipelineApi.Stage {
+		return &pipelineApi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: pipelineApi.StageSpec{
+				Name:        name,
+				CdPipeline:  "pipeline",
+				ClusterName: pipelineApi.InCluster,
+				Namespace:   name + "-ns",
+				Order:       order,
+			},
+		}
+	}
+
 	tests := []struct {
-		name    string
-		obj     runtime.Object
-		client  func(t *testing.T) client.Client
-		wantErr require.ErrorAssertionFunc
+		name          string
+		obj           runtime.Object
+		client        func(t *testing.T) client.Client
+		remoteClients map[string]client.Client
+		wantWarnings  admission.Warnings
+		wantErr       require.ErrorAssertionFunc
 	}{
 		{
 			name: "valid stage, no namespace conflict",
@@ -44,6 +75,7 @@ func TestStageValidationWebhook_ValidateCreate(t *testing.T) {
 					CdPipeline:  "pipeline",
 					ClusterName: pipelineApi.InCluster,
 					Namespace:   "stage1-ns",
+					Order:       2,
 				},
 			},
 			client: func(t *testing.T) client.Client {
@@ -70,10 +102,12 @@ func TestStageValidationWebhook_ValidateCreate(t *testing.T) {
 						CdPipeline:  "pipeline",
 						ClusterName: "cluster2",
 						Namespace:   "stage1-ns",
+						Order:       1,
 					},
 				}
 
 				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					pipeline,
 					stageWithDifferentTargetNs,
 					stageWithDifferentClusterButSameTargetNs,
 				).Build()
@@ -109,16 +143,378 @@ func TestStageValidationWebhook_ValidateCreate(t *testing.T) {
 				}
 
 				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
-					stageWithSameTargetNs,
+					stageWithSameTargetNs,
+				).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "namespace stage1-ns is already used in CDPipeline pipeline Stage stage2")
+			},
+		},
+		{
+			name: "namespace conflict with additional stage cluster",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "stage1",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:        "stage1",
+					CdPipeline:  "pipeline",
+					ClusterName: pipelineApi.InCluster,
+					Namespace:   "stage1-ns",
+					Clusters: []pipelineApi.ClusterTarget{
+						{Name: "cluster2", Namespace: "shared-ns"},
+					},
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				stageWithSameTargetNs := &pipelineApi.Stage{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "stage2",
+						Namespace: "default",
+					},
+					Spec: pipelineApi.StageSpec{
+						Name:        "stage2",
+						CdPipeline:  "pipeline",
+						ClusterName: "cluster2",
+						Namespace:   "shared-ns",
+					},
+				}
+
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					stageWithSameTargetNs,
+				).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "namespace shared-ns is already used in CDPipeline pipeline Stage stage2")
+			},
+		},
+		{
+			name: "cluster specified more than once",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "stage1",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:        "stage1",
+					CdPipeline:  "pipeline",
+					ClusterName: pipelineApi.InCluster,
+					Namespace:   "stage1-ns",
+					Clusters: []pipelineApi.ClusterTarget{
+						{Name: pipelineApi.InCluster, Namespace: "stage1-ns-copy"},
+					},
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cluster in-cluster is specified more than once")
+			},
+		},
+		{
+			name: "empty cluster name is the same cluster as in-cluster",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "stage1",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:       "stage1",
+					CdPipeline: "pipeline",
+					Namespace:  "stage1-ns",
+					Clusters: []pipelineApi.ClusterTarget{
+						{Name: pipelineApi.InCluster, Namespace: "stage1-ns-copy"},
+					},
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cluster in-cluster is specified more than once")
+			},
+		},
+		{
+			name: "namespace conflict with stage with empty cluster name",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "stage1",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:        "stage1",
+					CdPipeline:  "pipeline",
+					ClusterName: "cluster2",
+					Namespace:   "stage1-ns",
+					Clusters: []pipelineApi.ClusterTarget{
+						{Name: pipelineApi.InCluster, Namespace: "shared-ns"},
+					},
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				stageWithSameTargetNs := &pipelineApi.Stage{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "stage2",
+						Namespace: "default",
+					},
+					Spec: pipelineApi.StageSpec{
+						Name:       "stage2",
+						CdPipeline: "pipeline",
+						Namespace:  "shared-ns",
+					},
+				}
+
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					stageWithSameTargetNs,
+				).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "namespace shared-ns is already used in CDPipeline pipeline Stage stage2")
+			},
+		},
+		{
+			name: "namespace already used in the cluster",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "stage1",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:        "stage1",
+					CdPipeline:  "pipeline",
+					ClusterName: pipelineApi.InCluster,
+					Namespace:   "ns1",
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				ns1 := &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "ns1",
+						Labels: map[string]string{
+							util.TenantLabelName: "ns1",
+						},
+					},
+				}
+
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns1).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "namespace ns1 is already used in the cluster")
+			},
+		},
+		{
+			name: "cluster secret doesn't exist",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "stage1",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:        "stage1",
+					CdPipeline:  "pipeline",
+					ClusterName: "prod-clsuter",
+					Namespace:   "stage1-ns",
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cluster prod-clsuter is not found")
+			},
+		},
+		{
+			name: "secret is not a cluster secret",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "stage1",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:        "stage1",
+					CdPipeline:  "pipeline",
+					ClusterName: "prod-cluster",
+					Namespace:   "stage1-ns",
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "prod-cluster",
+							Namespace: "default",
+						},
+					},
+				).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "secret prod-cluster doesn't have label app.edp.epam.com/secret-type=cluster")
+			},
+		},
+		{
+			name: "cluster is not connected",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "stage1",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:        "stage1",
+					CdPipeline:  "pipeline",
+					ClusterName: "prod-cluster",
+					Namespace:   "stage1-ns",
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					newClusterSecret("prod-cluster", "false", "connection refused"),
+				).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cluster prod-cluster is not connected: connection refused")
+			},
+		},
+		{
+			name: "valid stage in the IRSA cluster",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "stage1",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:        "stage1",
+					CdPipeline:  "pipeline",
+					ClusterName: "eks",
+					Namespace:   "stage1-ns",
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				irsaSecret := newClusterSecret("eks-cluster", "true", "")
+				irsaSecret.UID = "eks-cluster-uid"
+
+				kubeConfSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "eks",
+						Namespace: "default",
+					},
+					Data: map[string][]byte{"config": []byte("kubeconfig")},
+				}
+				require.NoError(t, controllerutil.SetControllerReference(irsaSecret, kubeConfSecret, scheme))
+
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline, irsaSecret, kubeConfSecret).Build()
+			},
+			remoteClients: map[string]client.Client{
+				"eks": fake.NewClientBuilder().WithScheme(scheme).Build(),
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "IRSA cluster is not connected",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "stage1",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:        "stage1",
+					CdPipeline:  "pipeline",
+					ClusterName: "eks",
+					Namespace:   "stage1-ns",
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				irsaSecret := newClusterSecret("eks-cluster", "false", "token expired")
+				irsaSecret.UID = "eks-cluster-uid"
+
+				kubeConfSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "eks",
+						Namespace: "default",
+					},
+				}
+				require.NoError(t, controllerutil.SetControllerReference(irsaSecret, kubeConfSecret, scheme))
+
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(irsaSecret, kubeConfSecret).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cluster eks-cluster is not connected: token expired")
+			},
+		},
+		{
+			name: "namespace already used in the remote cluster",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "stage1",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:        "stage1",
+					CdPipeline:  "pipeline",
+					ClusterName: "prod-cluster",
+					Namespace:   "ns1",
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					newClusterSecret("prod-cluster", "true", ""),
+				).Build()
+			},
+			remoteClients: map[string]client.Client{
+				"prod-cluster": fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					&corev1.Namespace{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "ns1",
+							Labels: map[string]string{
+								util.TenantLabelName: "ns1",
+							},
+						},
+					},
+				).Build(),
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "namespace ns1 is already used in the cluster prod-cluster")
+			},
+		},
+		{
+			name: "valid stage in the remote cluster",
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "stage1",
+					Namespace: "default",
+				},
+				Spec: pipelineApi.StageSpec{
+					Name:        "stage1",
+					CdPipeline:  "pipeline",
+					ClusterName: "prod-cluster",
+					Namespace:   "ns1",
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					pipeline,
+					newClusterSecret("prod-cluster", "true", ""),
 				).Build()
 			},
-			wantErr: func(t require.TestingT, err error, i ...interface{}) {
-				require.Error(t, err)
-				require.Contains(t, err.Error(), "namespace stage1-ns is already used in CDPipeline pipeline Stage stage2")
+			remoteClients: map[string]client.Client{
+				"prod-cluster": fake.NewClientBuilder().WithScheme(scheme).Build(),
 			},
+			wantErr: require.NoError,
 		},
 		{
-			name: "namespace already used in the cluster",
+			name: "reserved namespace",
 			obj: &pipelineApi.Stage{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "stage1",
@@ -128,25 +524,146 @@ func TestStageValidationWebhook_ValidateCreate(t *testing.T) {
 					Name:        "stage1",
 					CdPipeline:  "pipeline",
 					ClusterName: pipelineApi.InCluster,
-					Namespace:   "ns1",
+					Namespace:   "kube-system",
 				},
 			},
 			client: func(t *testing.T) client.Client {
-				ns1 := &corev1.Namespace{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "ns1",
-						Labels: map[string]string{
-							util.TenantLabelName: "ns1",
-						},
-					},
-				}
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "namespace kube-system is reserved")
+			},
+		},
+		{
+			name: "access control role is not allowed",
+			obj: func() *pipelineApi.Stage {
+				s := newOrderedStage("dev", 0)
+				s.Spec.AccessControl = []pipelineApi.AccessControlBinding{{
+					Name:     "admins",
+					Subjects: []pipelineApi.AccessControlSubject{{Kind: "Group", Name: "admins"}},
+					RoleRef:  pipelineApi.AccessControlRoleRef{Name: "cluster-admin"},
+				}}
 
-				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns1).Build()
+				return s
+			}(),
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).Build()
 			},
 			wantErr: func(t require.TestingT, err error, i ...interface{}) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "namespace ns1 is already used in the cluster")
+				require.Contains(t, err.Error(), "role cluster-admin of access control binding admins is not allowed")
+			},
+		},
+		{
+			name: "access control ServiceAccount is from another namespace",
+			obj: func() *pipelineApi.Stage {
+				s := newOrderedStage("dev", 0)
+				s.Spec.AccessControl = []pipelineApi.AccessControlBinding{{
+					Name: "deployers",
+					Subjects: []pipelineApi.AccessControlSubject{{
+						Kind:      "ServiceAccount",
+						Name:      "deployer",
+						Namespace: "kube-system",
+					}},
+					RoleRef: pipelineApi.AccessControlRoleRef{Name: "edit"},
+				}}
+
+				return s
+			}(),
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "ServiceAccount kube-system/deployer of access control binding deployers")
+			},
+		},
+		{
+			name: "cdpipeline doesn't exist",
+			obj:  newOrderedStage("dev", 0),
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cdpipeline pipeline doesn't exist")
+			},
+		},
+		{
+			name: "order is already used",
+			obj:  newOrderedStage("qa", 0),
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					pipeline,
+					newOrderedStage("dev", 0),
+				).Build()
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "order 0 is already used by Stage dev in CDPipeline pipeline")
+			},
+		},
+		{
+			name: "order is not contiguous",
+			obj:  newOrderedStage("prod", 2),
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					pipeline,
+					newOrderedStage("dev", 0),
+				).Build()
+			},
+			wantWarnings: admission.Warnings{
+				"stages of CDPipeline pipeline must have contiguous order starting from 0, order 1 is missing, " +
+					"Stage prod won't be processed until the missing Stage is created",
 			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "order of terminating stage can be reused",
+			obj:  newOrderedStage("qa", 1),
+			client: func(t *testing.T) client.Client {
+				terminating := newOrderedStage("old-qa", 1)
+				terminating.Finalizers = []string{"test"}
+				terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					pipeline,
+					newOrderedStage("dev", 0),
+					terminating,
+				).Build()
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "order of stages from another cdpipeline is ignored",
+			obj:  newOrderedStage("qa", 1),
+			client: func(t *testing.T) client.Client {
+				otherStage := newOrderedStage("other", 1)
+				otherStage.Spec.CdPipeline = "other-pipeline"
+
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					pipeline,
+					newOrderedStage("dev", 0),
+					otherStage,
+				).Build()
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "order is not validated during reordering",
+			obj:  newOrderedStage("qa", 1),
+			client: func(t *testing.T) client.Client {
+				reorderedPipeline := pipeline.DeepCopy()
+				reorderedPipeline.Annotations = map[string]string{pipelineApi.StageReorderAnnotation: "true"}
+
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					reorderedPipeline,
+					newOrderedStage("dev", 0),
+					newOrderedStage("prod", 1),
+				).Build()
+			},
+			wantErr: require.NoError,
 		},
 		{
 			name: "invalid object given",
@@ -165,17 +682,67 @@ func TestStageValidationWebhook_ValidateCreate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			r := NewStageValidationWebhook(tt.client(t))
+			r := NewStageValidationWebhook(tt.client(t), record.NewFakeRecorder(10))
+			if tt.remoteClients != nil {
+				r.clientProvider = fakeClusterClientProvider{
+					internal: r.client,
+					remote:   tt.remoteClients,
+				}
+			}
+
 			w, err := r.ValidateCreate(context.Background(), tt.obj)
 
-			assert.Nil(t, w)
+			assert.Equal(t, tt.wantWarnings, w)
 			tt.wantErr(t, err)
 		})
 	}
 }
 
 func TestStageValidationWebhook_ValidateUpdate(t *testing.T) {
-	cl := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	scheme := runtime.NewScheme()
+	require.NoError(t, pipelineApi.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newClusterSecret("eu-cluster", "true", ""),
+		&pipelineApi.CDPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pipeline",
+				Namespace: "default",
+			},
+		},
+		&pipelineApi.CDPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "reordered-pipeline",
+				Namespace:   "default",
+				Annotations: map[string]string{pipelineApi.StageReorderAnnotation: "true"},
+			},
+		},
+	).Build()
+	remoteClients := map[string]client.Client{
+		"eu-cluster": fake.NewClientBuilder().WithScheme(scheme).Build(),
+	}
+
+	newStage := func(modify func(s *pipelineApi.Stage)) *pipelineApi.Stage {
+		s := &pipelineApi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "stage",
+				Namespace: "default",
+			},
+			Spec: pipelineApi.StageSpec{
+				Name:        "dev",
+				CdPipeline:  "pipeline",
+				Namespace:   "default-pipeline-dev",
+				ClusterName: pipelineApi.InCluster,
+			},
+		}
+
+		if modify != nil {
+			modify(s)
+		}
+
+		return s
+	}
 
 	type args struct {
 		oldObj runtime.Object
@@ -237,11 +804,155 @@ func TestStageValidationWebhook_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: require.NoError,
 		},
+		{
+			name: "spec.namespace is immutable",
+			args: args{
+				oldObj: newStage(nil),
+				newObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.Namespace = "new-ns"
+				}),
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "spec.namespace is immutable")
+			},
+		},
+		{
+			name: "spec.clusterName is immutable",
+			args: args{
+				oldObj: newStage(nil),
+				newObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.ClusterName = "eu-cluster"
+				}),
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "spec.clusterName is immutable")
+			},
+		},
+		{
+			name: "spec.cdPipeline is immutable",
+			args: args{
+				oldObj: newStage(nil),
+				newObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.CdPipeline = "pipeline2"
+				}),
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "spec.cdPipeline is immutable")
+			},
+		},
+		{
+			name: "spec.name is immutable",
+			args: args{
+				oldObj: newStage(nil),
+				newObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.Name = "qa"
+				}),
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "spec.name is immutable")
+			},
+		},
+		{
+			name: "empty cluster name is the same as in-cluster",
+			args: args{
+				oldObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.ClusterName = ""
+				}),
+				newObj: newStage(nil),
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "cluster can't be removed",
+			args: args{
+				oldObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.Clusters = []pipelineApi.ClusterTarget{{Name: "eu-cluster"}}
+				}),
+				newObj: newStage(nil),
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cluster eu-cluster can't be removed from spec.clusters")
+			},
+		},
+		{
+			name: "cluster namespace is immutable",
+			args: args{
+				oldObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.Clusters = []pipelineApi.ClusterTarget{{Name: "eu-cluster"}}
+				}),
+				newObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.Clusters = []pipelineApi.ClusterTarget{{Name: "eu-cluster", Namespace: "eu-ns"}}
+				}),
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "namespace of cluster eu-cluster in spec.clusters is immutable")
+			},
+		},
+		{
+			name: "cluster can be added",
+			args: args{
+				oldObj: newStage(nil),
+				newObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.Clusters = []pipelineApi.ClusterTarget{{Name: "eu-cluster"}}
+				}),
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "added cluster is validated",
+			args: args{
+				oldObj: newStage(nil),
+				newObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.Clusters = []pipelineApi.ClusterTarget{{Name: "us-cluster"}}
+				}),
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cluster us-cluster is not found")
+			},
+		},
+		{
+			name: "order can't be changed without reordering",
+			args: args{
+				oldObj: newStage(nil),
+				newObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.Order = 1
+				}),
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "spec.order can be changed only if CDPipeline pipeline has annotation")
+			},
+		},
+		{
+			name: "order can be changed during reordering",
+			args: args{
+				oldObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.CdPipeline = "reordered-pipeline"
+				}),
+				newObj: newStage(func(s *pipelineApi.Stage) {
+					s.Spec.CdPipeline = "reordered-pipeline"
+					s.Spec.Order = 1
+				}),
+			},
+			wantErr: require.NoError,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cd := NewStageValidationWebhook(cl)
+			cd := NewStageValidationWebhook(cl, record.NewFakeRecorder(10))
+			cd.clientProvider = fakeClusterClientProvider{
+				internal: cl,
+				remote:   remoteClients,
+			}
+
 			w, err := cd.ValidateUpdate(context.Background(), tt.args.oldObj, tt.args.newObj)
 
 			assert.Nil(t, w)
@@ -251,51 +962,200 @@ func TestStageValidationWebhook_ValidateUpdate(t *testing.T) {
 }
 
 func TestStageValidationWebhook_ValidateDelete(t *testing.T) {
-	cl := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	t.Parallel()
 
-	type args struct {
-		obj runtime.Object
+	scheme := runtime.NewScheme()
+	require.NoError(t, pipelineApi.AddToScheme(scheme))
+
+	pipeline := &pipelineApi.CDPipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pipeline",
+			Namespace: "default",
+		},
+	}
+
+	newOrderedStage := func(name string, order int) *pipelineApi.Stage {
+		return &pipelineApi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: pipelineApi.StageSpec{
+				Name:       name,
+				CdPipeline: "pipeline",
+				Order:      order,
+			},
+		}
 	}
 
 	tests := []struct {
 		name    string
-		args    args
-		wantErr assert.ErrorAssertionFunc
+		obj     runtime.Object
+		objects []client.Object
+		wantErr require.ErrorAssertionFunc
 	}{
 		{
 			name: "validating Stage delete with protected label",
-			args: args{
-				obj: &pipelineApi.Stage{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "stage",
-						Labels: map[string]string{
-							protectedLabel: deleteOperation,
-						},
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "stage",
+					Labels: map[string]string{
+						protectedLabel: deleteOperation,
 					},
 				},
 			},
-			wantErr: assert.Error,
+			wantErr: require.Error,
 		},
 		{
 			name: "validating Stage delete without protected label",
-			args: args{
-				obj: &pipelineApi.Stage{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "stage",
-					},
+			obj: &pipelineApi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "stage",
 				},
 			},
-			wantErr: assert.NoError,
+			wantErr: require.NoError,
+		},
+		{
+			name:    "last stage is deleted",
+			obj:     newOrderedStage("qa", 1),
+			objects: []client.Object{pipeline, newOrderedStage("dev", 0), newOrderedStage("qa", 1)},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "stage followed by other stages can't be deleted",
+			obj:     newOrderedStage("dev", 0),
+			objects: []client.Object{pipeline, newOrderedStage("dev", 0), newOrderedStage("qa", 1)},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "stage dev can't be deleted, because it is followed by Stage qa")
+			},
+		},
+		{
+			name: "stage followed by terminating stages is deleted",
+			obj:  newOrderedStage("dev", 0),
+			objects: func() []client.Object {
+				terminating := newOrderedStage("qa", 1)
+				terminating.Finalizers = []string{"test"}
+				terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+				return []client.Object{pipeline, newOrderedStage("dev", 0), terminating}
+			}(),
+			wantErr: require.NoError,
+		},
+		{
+			name: "stage is deleted with cdpipeline",
+			obj:  newOrderedStage("dev", 0),
+			objects: func() []client.Object {
+				deleted := pipeline.DeepCopy()
+				deleted.Finalizers = []string{"test"}
+				deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+				return []client.Object{deleted, newOrderedStage("dev", 0), newOrderedStage("qa", 1)}
+			}(),
+			wantErr: require.NoError,
+		},
+		{
+			name: "stage is deleted during reordering",
+			obj:  newOrderedStage("dev", 0),
+			objects: func() []client.Object {
+				reordered := pipeline.DeepCopy()
+				reordered.Annotations = map[string]string{pipelineApi.StageReorderAnnotation: "true"}
+
+				return []client.Object{reordered, newOrderedStage("dev", 0), newOrderedStage("qa", 1)}
+			}(),
+			wantErr: require.NoError,
+		},
+		{
+			name:    "invalid object given",
+			obj:     &codebaseApi.Codebase{},
+			wantErr: require.Error,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			st := NewStageValidationWebhook(cl)
-			w, err := st.ValidateDelete(context.Background(), tt.args.obj)
+			t.Parallel()
+
+			st := NewStageValidationWebhook(
+				fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+				record.NewFakeRecorder(10),
+			)
+			w, err := st.ValidateDelete(context.Background(), tt.obj)
 
 			assert.Nil(t, w)
 			tt.wantErr(t, err)
 		})
 	}
 }
+
+type fakeClusterClientProvider struct {
+	internal client.Client
+	remote   map[string]client.Client
+}
+
+func (p fakeClusterClientProvider) GetClusterClient(
+	_ context.Context,
+	_ string,
+	clusterName string,
+	_ client.Options,
+) (client.Client, error) {
+	if clusterName == pipelineApi.InCluster {
+		return p.internal, nil
+	}
+
+	cl, ok := p.remote[clusterName]
+	if !ok {
+		return nil, fmt.Errorf("cluster %s is not found", clusterName)
+	}
+
+	return cl, nil
+}
+
+func newClusterSecret(name, connected, connectionErr string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				multiclusterclient.ClusterSecretTypeLabel: multiclusterclient.ClusterSecretTypeCluster,
+			},
+			Annotations: map[string]string{
+				multiclusterclient.ClusterSecretConnectionAnnotation: connected,
+				multiclusterclient.ClusterSecretErrorAnnotation:      connectionErr,
+			},
+		},
+	}
+}
+
+func Test_validateAccessControl_HNC(t *testing.T) {
+	t.Setenv(platform.TypeEnv, platform.Kubernetes)
+	t.Setenv(platform.TenancyEngineEnv, platform.TenancyEngineHNC)
+
+	newStage := func(clusterName string, bindings ...pipelineApi.AccessControlBinding) *pipelineApi.Stage {
+		return &pipelineApi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dev",
+				Namespace: "default",
+			},
+			Spec: pipelineApi.StageSpec{
+				Name:          "dev",
+				ClusterName:   clusterName,
+				Namespace:     "default-dev",
+				AccessControl: bindings,
+			},
+		}
+	}
+
+	viewers := pipelineApi.AccessControlBinding{
+		Name:     "viewers",
+		Subjects: []pipelineApi.AccessControlSubject{{Kind: "Group", Name: "viewers"}},
+		RoleRef:  pipelineApi.AccessControlRoleRef{Name: "view"},
+	}
+
+	err := validateAccessControl(newStage(pipelineApi.InCluster, viewers))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "accessControl is not supported with the HNC tenancy engine")
+
+	require.NoError(t, validateAccessControl(newStage(pipelineApi.InCluster)))
+	require.NoError(t, validateAccessControl(newStage("remote-cluster", viewers)), "remote cluster isn't managed by HNC")
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+// setUserAnnotations sets the requesting user to the created-by and last-modified-by annotations.
+// The last-modified-by annotation is changed only if the spec is updated.
+// Users can't change the annotations themselves, the values from the old object are kept.
+// oldObj is an empty object of the same type, it is filled from the admission request.
+func setUserAnnotations(ctx context.Context, obj, oldObj client.Object) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		// The object is not changed through the admission, e.g., in tests.
+		return nil
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 2)
+	}
+
+	user := req.UserInfo.Username
+
+	switch req.Operation {
+	case admissionv1.Create:
+		annotations[pipelineApi.CreatedByAnnotation] = user
+		annotations[pipelineApi.LastModifiedByAnnotation] = user
+	case admissionv1.Update:
+		if err = json.Unmarshal(req.OldObject.Raw, oldObj); err != nil {
+			return fmt.Errorf("failed to decode old object: %w", err)
+		}
+
+		for _, key := range []string{pipelineApi.CreatedByAnnotation, pipelineApi.LastModifiedByAnnotation} {
+			if val, ok := oldObj.GetAnnotations()[key]; ok {
+				annotations[key] = val
+			} else {
+				delete(annotations, key)
+			}
+		}
+
+		if isSpecUpdated(oldObj, obj) {
+			annotations[pipelineApi.LastModifiedByAnnotation] = user
+		}
+	default:
+		return nil
+	}
+
+	obj.SetAnnotations(annotations)
+
+	return nil
+}
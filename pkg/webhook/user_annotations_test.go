@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+)
+
+func Test_setUserAnnotations(t *testing.T) {
+	t.Parallel()
+
+	newStage := func(description string, annotations map[string]string) *pipelineApi.Stage {
+		return &pipelineApi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "stage",
+				Namespace:   "default",
+				Annotations: annotations,
+			},
+			Spec: pipelineApi.StageSpec{
+				Description: description,
+			},
+		}
+	}
+
+	requestContext := func(t *testing.T, operation admissionv1.Operation, oldObj runtime.Object) context.Context {
+		req := admissionv1.AdmissionRequest{
+			Operation: operation,
+			UserInfo: authenticationv1.UserInfo{
+				Username: "jane",
+			},
+		}
+
+		if oldObj != nil {
+			raw, err := json.Marshal(oldObj)
+			require.NoError(t, err)
+
+			req.OldObject = runtime.RawExtension{Raw: raw}
+		}
+
+		return admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: req})
+	}
+
+	tests := []struct {
+		name    string
+		ctx     func(t *testing.T) context.Context
+		obj     *pipelineApi.Stage
+		wantErr require.ErrorAssertionFunc
+		want    map[string]string
+	}{
+		{
+			name: "create sets both annotations",
+			ctx: func(t *testing.T) context.Context {
+				return requestContext(t, admissionv1.Create, nil)
+			},
+			obj:     newStage("stage", nil),
+			wantErr: require.NoError,
+			want: map[string]string{
+				pipelineApi.CreatedByAnnotation:      "jane",
+				pipelineApi.LastModifiedByAnnotation: "jane",
+			},
+		},
+		{
+			name: "create overrides annotations set by user",
+			ctx: func(t *testing.T) context.Context {
+				return requestContext(t, admissionv1.Create, nil)
+			},
+			obj: newStage("stage", map[string]string{
+				pipelineApi.CreatedByAnnotation: "admin",
+				"custom":                        "value",
+			}),
+			wantErr: require.NoError,
+			want: map[string]string{
+				pipelineApi.CreatedByAnnotation:      "jane",
+				pipelineApi.LastModifiedByAnnotation: "jane",
+				"custom":                             "value",
+			},
+		},
+		{
+			name: "spec update sets last modified by",
+			ctx: func(t *testing.T) context.Context {
+				return requestContext(t, admissionv1.Update, newStage("stage", map[string]string{
+					pipelineApi.CreatedByAnnotation:      "john",
+					pipelineApi.LastModifiedByAnnotation: "john",
+				}))
+			},
+			obj: newStage("stage 2", map[string]string{
+				pipelineApi.CreatedByAnnotation:      "john",
+				pipelineApi.LastModifiedByAnnotation: "john",
+			}),
+			wantErr: require.NoError,
+			want: map[string]string{
+				pipelineApi.CreatedByAnnotation:      "john",
+				pipelineApi.LastModifiedByAnnotation: "jane",
+			},
+		},
+		{
+			name: "metadata update keeps annotations",
+			ctx: func(t *testing.T) context.Context {
+				return requestContext(t, admissionv1.Update, newStage("stage", map[string]string{
+					pipelineApi.CreatedByAnnotation:      "john",
+					pipelineApi.LastModifiedByAnnotation: "john",
+				}))
+			},
+			obj: newStage("stage", map[string]string{
+				pipelineApi.CreatedByAnnotation:      "john",
+				pipelineApi.LastModifiedByAnnotation: "john",
+				"custom":                             "value",
+			}),
+			wantErr: require.NoError,
+			want: map[string]string{
+				pipelineApi.CreatedByAnnotation:      "john",
+				pipelineApi.LastModifiedByAnnotation: "john",
+				"custom":                             "value",
+			},
+		},
+		{
+			name: "user can't change annotations",
+			ctx: func(t *testing.T) context.Context {
+				return requestContext(t, admissionv1.Update, newStage("stage", map[string]string{
+					pipelineApi.CreatedByAnnotation: "john",
+				}))
+			},
+			obj: newStage("stage", map[string]string{
+				pipelineApi.CreatedByAnnotation:      "admin",
+				pipelineApi.LastModifiedByAnnotation: "admin",
+			}),
+			wantErr: require.NoError,
+			want: map[string]string{
+				pipelineApi.CreatedByAnnotation: "john",
+			},
+		},
+		{
+			name: "object is not changed without admission request",
+			ctx: func(t *testing.T) context.Context {
+				return context.Background()
+			},
+			obj:     newStage("stage", nil),
+			wantErr: require.NoError,
+			want:    nil,
+		},
+		{
+			name: "invalid old object",
+			ctx: func(t *testing.T) context.Context {
+				return admission.NewContextWithRequest(context.Background(), admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Update,
+						OldObject: runtime.RawExtension{Raw: []byte("invalid")},
+					},
+				})
+			},
+			obj: newStage("stage", nil),
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to decode old object")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := setUserAnnotations(tt.ctx(t), tt.obj, &pipelineApi.Stage{})
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, tt.obj.GetAnnotations())
+		})
+	}
+}
@@ -8,13 +8,28 @@ import (
 
 // RegisterValidationWebHook registers a new webhook for validating CRD.
 func RegisterValidationWebHook(mgr ctrl.Manager) error {
-	if err := NewStageValidationWebhook(mgr.GetClient()).SetupWebhookWithManager(mgr); err != nil {
+	recorder := mgr.GetEventRecorderFor("cd-pipeline-operator-webhook")
+
+	if err := NewStageValidationWebhook(mgr.GetClient(), recorder).SetupWebhookWithManager(mgr); err != nil {
 		return fmt.Errorf("failed to create Stage webhook: %w", err)
 	}
 
-	if err := NewCDPipelineValidationWebhook(mgr.GetClient()).SetupWebhookWithManager(mgr); err != nil {
+	if err := NewCDPipelineValidationWebhook(mgr.GetClient(), recorder).SetupWebhookWithManager(mgr); err != nil {
 		return fmt.Errorf("failed to create CDpipeline webhook: %w", err)
 	}
 
 	return nil
 }
+
+// RegisterDefaultingWebHook registers a new webhook for setting default values to CRD.
+func RegisterDefaultingWebHook(mgr ctrl.Manager) error {
+	if err := NewStageDefaulter(mgr.GetClient(), mgr.GetScheme()).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to create Stage defaulting webhook: %w", err)
+	}
+
+	if err := NewCDPipelineDefaulter().SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to create CDPipeline defaulting webhook: %w", err)
+	}
+
+	return nil
+}